@@ -26,6 +26,9 @@ const (
 )
 
 type Tracker struct {
+	// Client sends the events, http.DefaultClient if nil.
+	Client *http.Client
+
 	wg sync.WaitGroup
 }
 
@@ -91,7 +94,10 @@ func (t *Tracker) sendEvent(event *event) {
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		println(err.Error())
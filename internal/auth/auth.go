@@ -38,17 +38,28 @@ var requiredScopes = []string{
 	"create:actions", "delete:actions", "read:actions", "update:actions",
 	"create:organizations", "delete:organizations", "read:organizations", "update:organizations", "read:organization_members", "read:organization_member_roles",
 	"read:prompts", "update:prompts",
+	"read:attack_protection", "update:attack_protection",
+	"read:email_provider", "create:email_provider", "update:email_provider", "delete:email_provider",
+	"read:guardian_factors", "update:tenant_settings", "read:client_grants",
 }
 
+// loginScopes are requested on every login, whichever other scopes are.
+var loginScopes = []string{"openid", "offline_access"}
+
 type Authenticator struct {
 	Audience           string
 	ClientID           string
 	DeviceCodeEndpoint string
 	OauthTokenEndpoint string
+
+	// Client sends the requests, http.DefaultClient if nil.
+	Client *http.Client
 }
 
 // SecretStore provides access to stored sensitive data.
 type SecretStore interface {
+	// Set sets the secret
+	Set(namespace, key, value string) error
 	// Get gets the secret
 	Get(namespace, key string) (string, error)
 	// Delete removes the secret
@@ -61,6 +72,9 @@ type Result struct {
 	RefreshToken string
 	AccessToken  string
 	ExpiresIn    int64
+	// Scopes are the scopes that were granted, which can be fewer than
+	// the ones requested.
+	Scopes []string
 }
 
 type State struct {
@@ -85,15 +99,56 @@ func RequiredScopesMin() []string {
 	return min
 }
 
+// ReadOnlyScopes returns the scopes used for a login which can't change
+// anything on the tenant.
+func ReadOnlyScopes() []string {
+	scopes := append([]string{}, loginScopes...)
+	for _, s := range requiredScopes {
+		if strings.HasPrefix(s, "read:") {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// LoginScopes validates the given scopes against the ones the CLI can
+// request, and adds the ones every login needs.
+func LoginScopes(scopes []string) ([]string, error) {
+	res := append([]string{}, loginScopes...)
+	for _, s := range scopes {
+		if !contains(requiredScopes, s) {
+			return nil, fmt.Errorf("unknown scope %q", s)
+		}
+		if !contains(res, s) {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *State) IntervalDuration() time.Duration {
 	return time.Duration(s.Interval+waitThresholdInSeconds) * time.Second
 }
 
 // Start kicks-off the device authentication flow
-// by requesting a device code from Auth0,
+// by requesting a device code from Auth0 for the given scopes, or all the
+// required ones if none are given.
 // The returned state contains the URI for the next step of the flow.
-func (a *Authenticator) Start(ctx context.Context) (State, error) {
-	s, err := a.getDeviceCode(ctx)
+func (a *Authenticator) Start(ctx context.Context, scopes []string) (State, error) {
+	if len(scopes) == 0 {
+		scopes = requiredScopes
+	}
+
+	s, err := a.getDeviceCode(ctx, scopes)
 	if err != nil {
 		return State{}, fmt.Errorf("cannot get device code: %w", err)
 	}
@@ -113,7 +168,7 @@ func (a *Authenticator) Wait(ctx context.Context, state State) (Result, error) {
 				"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
 				"device_code": {state.DeviceCode},
 			}
-			r, err := http.PostForm(a.OauthTokenEndpoint, data)
+			r, err := a.client().PostForm(a.OauthTokenEndpoint, data)
 			if err != nil {
 				return Result{}, fmt.Errorf("cannot get device code: %w", err)
 			}
@@ -153,18 +208,19 @@ func (a *Authenticator) Wait(ctx context.Context, state State) (Result, error) {
 				ExpiresIn:    res.ExpiresIn,
 				Tenant:       ten,
 				Domain:       domain,
+				Scopes:       strings.Fields(res.Scope),
 			}, nil
 		}
 	}
 }
 
-func (a *Authenticator) getDeviceCode(ctx context.Context) (State, error) {
+func (a *Authenticator) getDeviceCode(ctx context.Context, scopes []string) (State, error) {
 	data := url.Values{
 		"client_id": {a.ClientID},
-		"scope":     {strings.Join(requiredScopes, " ")},
+		"scope":     {strings.Join(scopes, " ")},
 		"audience":  {a.Audience},
 	}
-	r, err := http.PostForm(a.DeviceCodeEndpoint, data)
+	r, err := a.client().PostForm(a.DeviceCodeEndpoint, data)
 	if err != nil {
 		return State{}, fmt.Errorf("cannot get device code: %w", err)
 	}
@@ -178,6 +234,13 @@ func (a *Authenticator) getDeviceCode(ctx context.Context) (State, error) {
 	return res, nil
 }
 
+func (a *Authenticator) client() *http.Client {
+	if a.Client == nil {
+		return http.DefaultClient
+	}
+	return a.Client
+}
+
 func parseTenant(accessToken string) (tenant, domain string, err error) {
 	parts := strings.Split(accessToken, ".")
 	v, err := base64.RawURLEncoding.DecodeString(parts[1])
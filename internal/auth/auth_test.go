@@ -1,6 +1,9 @@
 package auth
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestRequiredScopes(t *testing.T) {
 	t.Run("verify CRUD", func(t *testing.T) {
@@ -51,3 +54,37 @@ func strInArray(haystack []string, needle string) bool {
 
 	return false
 }
+
+func TestReadOnlyScopes(t *testing.T) {
+	for _, s := range ReadOnlyScopes() {
+		if s != "openid" && s != "offline_access" && !strings.HasPrefix(s, "read:") {
+			t.Fatalf("unexpected scope: %q", s)
+		}
+	}
+
+	for _, s := range []string{"offline_access", "read:clients", "read:logs"} {
+		if !strInArray(ReadOnlyScopes(), s) {
+			t.Fatalf("wanted scope: %q, list: %+v", s, ReadOnlyScopes())
+		}
+	}
+}
+
+func TestLoginScopes(t *testing.T) {
+	t.Run("adds the scopes every login needs", func(t *testing.T) {
+		got, err := LoginScopes([]string{"read:users", "openid", "read:users"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"openid", "offline_access", "read:users"}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Fatalf("wanted: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("rejects unknown scopes", func(t *testing.T) {
+		if _, err := LoginScopes([]string{"read:users", "read:everything"}); err == nil {
+			t.Fatal("wanted an error")
+		}
+	})
+}
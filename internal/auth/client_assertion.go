@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	// clientAssertionLifetime is how long a client assertion can be used
+	// for, which only needs to cover a single token request.
+	clientAssertionLifetime = time.Minute
+)
+
+// ClientAssertionAlgorithms are the signing algorithms Auth0 accepts for
+// client assertions.
+var ClientAssertionAlgorithms = []string{"RS256", "RS384", "PS256"}
+
+// PrivateKeyJWT authenticates an application with a JWT signed with its
+// private key rather than with a client secret. See
+// https://auth0.com/docs/get-started/authentication-and-authorization-flow/authenticate-with-private-key-jwt
+type PrivateKeyJWT struct {
+	Domain   string
+	ClientID string
+
+	// PrivateKey is the PEM encoded RSA private key whose public key is
+	// registered with the application.
+	PrivateKey string
+
+	// KeyID identifies the key, when several are registered.
+	KeyID string
+
+	// Algorithm signs the assertion: RS256, RS384 or PS256.
+	Algorithm string
+}
+
+// Validate checks that the key can be used to sign assertions.
+func (p *PrivateKeyJWT) Validate() error {
+	if !containsAlgorithm(p.Algorithm) {
+		return fmt.Errorf("unsupported signing algorithm %q, use one of: %s", p.Algorithm, strings.Join(ClientAssertionAlgorithms, ", "))
+	}
+
+	_, err := parseRSAPrivateKey(p.PrivateKey)
+	return err
+}
+
+// Assertion returns a signed JWT identifying the application to the tenant.
+func (p *PrivateKeyJWT) Assertion(now time.Time) (string, error) {
+	key, err := parseRSAPrivateKey(p.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	t := jwt.New()
+	for k, v := range map[string]interface{}{
+		jwt.IssuerKey:     p.ClientID,
+		jwt.SubjectKey:    p.ClientID,
+		jwt.AudienceKey:   "https://" + p.Domain + "/",
+		jwt.JwtIDKey:      uuid.NewString(),
+		jwt.IssuedAtKey:   now,
+		jwt.ExpirationKey: now.Add(clientAssertionLifetime),
+	} {
+		if err := t.Set(k, v); err != nil {
+			return "", err
+		}
+	}
+
+	headers := jws.NewHeaders()
+	if p.KeyID != "" {
+		if err := headers.Set(jws.KeyIDKey, p.KeyID); err != nil {
+			return "", err
+		}
+	}
+
+	signed, err := jwt.Sign(t, jwa.SignatureAlgorithm(p.Algorithm), key, jwt.WithHeaders(headers))
+	if err != nil {
+		return "", fmt.Errorf("unable to sign the client assertion: %w", err)
+	}
+
+	return string(signed), nil
+}
+
+// Token gets an access token for the given audience with the client
+// credentials grant, authenticating with a client assertion.
+func (p *PrivateKeyJWT) Token(ctx context.Context, client *http.Client, audience string, scopes []string) (TokenResponse, error) {
+	assertion, err := p.Assertion(time.Now())
+	if err != nil {
+		return TokenResponse{}, err
+	}
+
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {p.ClientID},
+		"client_assertion_type": {clientAssertionType},
+		"client_assertion":      {assertion},
+		"audience":              {audience},
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+p.Domain+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	r, err := client.Do(req)
+	if err != nil {
+		return TokenResponse{}, fmt.Errorf("cannot get an access token: %w", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		b, _ := ioutil.ReadAll(r.Body)
+		return TokenResponse{}, fmt.Errorf("cannot get an access token: %s", string(b))
+	}
+
+	var res TokenResponse
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return TokenResponse{}, fmt.Errorf("cannot decode response: %w", err)
+	}
+
+	return res, nil
+}
+
+func parseRSAPrivateKey(p string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(p))
+	if block == nil {
+		return nil, errors.New("the private key isn't PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the private key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key must be an RSA key")
+	}
+
+	return rsaKey, nil
+}
+
+func containsAlgorithm(alg string) bool {
+	for _, a := range ClientAssertionAlgorithms {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
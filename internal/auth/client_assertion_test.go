@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPrivateKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(p)
+}
+
+func TestPrivateKeyJWT(t *testing.T) {
+	key, p := testPrivateKey(t)
+
+	t.Run("validates the key and algorithm", func(t *testing.T) {
+		assert.NoError(t, (&PrivateKeyJWT{PrivateKey: p, Algorithm: "RS256"}).Validate())
+		assert.EqualError(t, (&PrivateKeyJWT{PrivateKey: p, Algorithm: "HS256"}).Validate(), `unsupported signing algorithm "HS256", use one of: RS256, RS384, PS256`)
+		assert.EqualError(t, (&PrivateKeyJWT{PrivateKey: "secret", Algorithm: "RS256"}).Validate(), "the private key isn't PEM encoded")
+	})
+
+	t.Run("signs the client assertion", func(t *testing.T) {
+		pk := &PrivateKeyJWT{Domain: "travel0.auth0.com", ClientID: "my-client-id", PrivateKey: p, KeyID: "my-key", Algorithm: "PS256"}
+		now := time.Now()
+
+		assertion, err := pk.Assertion(now)
+		require.NoError(t, err)
+
+		token, err := jwt.ParseString(assertion, jwt.WithVerify(jwa.PS256, &key.PublicKey))
+		require.NoError(t, err)
+		assert.Equal(t, "my-client-id", token.Issuer())
+		assert.Equal(t, "my-client-id", token.Subject())
+		assert.Equal(t, []string{"https://travel0.auth0.com/"}, token.Audience())
+		assert.NotEmpty(t, token.JwtID())
+		assert.WithinDuration(t, now.Add(time.Minute), token.Expiration(), time.Second)
+
+		msg, err := jws.ParseString(assertion)
+		require.NoError(t, err)
+		assert.Equal(t, "my-key", msg.Signatures()[0].ProtectedHeaders().KeyID())
+	})
+
+	t.Run("gets a token with the client assertion", func(t *testing.T) {
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/oauth/token", r.URL.Path)
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+			assert.Equal(t, "my-client-id", r.Form.Get("client_id"))
+			assert.Equal(t, clientAssertionType, r.Form.Get("client_assertion_type"))
+			assert.NotEmpty(t, r.Form.Get("client_assertion"))
+			assert.Equal(t, "https://travel0.auth0.com/api/v2/", r.Form.Get("audience"))
+			assert.Equal(t, "read:clients read:logs", r.Form.Get("scope"))
+
+			_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "my-access-token", ExpiresIn: 86400, Scope: "read:clients"})
+		}))
+		defer ts.Close()
+
+		pk := &PrivateKeyJWT{Domain: strings.TrimPrefix(ts.URL, "https://"), ClientID: "my-client-id", PrivateKey: p, Algorithm: "RS256"}
+
+		res, err := pk.Token(context.Background(), ts.Client(), "https://travel0.auth0.com/api/v2/", []string{"read:clients", "read:logs"})
+		require.NoError(t, err)
+		assert.Equal(t, "my-access-token", res.AccessToken)
+		assert.Equal(t, 86400, res.ExpiresIn)
+		assert.Equal(t, "read:clients", res.Scope)
+	})
+
+	t.Run("fails when the token request is denied", func(t *testing.T) {
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+		}))
+		defer ts.Close()
+
+		pk := &PrivateKeyJWT{Domain: strings.TrimPrefix(ts.URL, "https://"), ClientID: "my-client-id", PrivateKey: p, Algorithm: "RS256"}
+
+		_, err := pk.Token(context.Background(), ts.Client(), "https://travel0.auth0.com/api/v2/", nil)
+		assert.EqualError(t, err, `cannot get an access token: {"error":"access_denied"}`)
+	})
+}
@@ -1,9 +1,18 @@
 package auth
 
 import (
+	"errors"
+
 	"github.com/zalando/go-keyring"
 )
 
+// ErrSecretNotFound is returned by every SecretStore when there is no secret
+// for the given namespace and key.
+var ErrSecretNotFound = keyring.ErrNotFound
+
+// keyringProbe is the key looked up to check whether the OS keyring works.
+const keyringProbe = "keyring-probe"
+
 type Keyring struct{}
 
 // Set sets the given key/value pair with the given namespace.
@@ -20,3 +29,10 @@ func (k *Keyring) Get(namespace, key string) (string, error) {
 func (k *Keyring) Delete(namespace, key string) error {
 	return keyring.Delete(namespace, key)
 }
+
+// KeyringAvailable reports whether the OS keyring can be used, which isn't the
+// case e.g. on Linux servers and containers without a Secret Service.
+func KeyringAvailable() bool {
+	_, err := keyring.Get(SecretsNamespace, keyringProbe)
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	secretsFileVersion = 1
+
+	// scrypt parameters recommended for interactive logins.
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrWrongPassphrase is returned when the secrets file can't be decrypted
+// with the given passphrase.
+var ErrWrongPassphrase = errors.New("unable to decrypt the secrets file: wrong passphrase")
+
+// FileSecretStore stores secrets in a file encrypted with a passphrase, for
+// machines without an OS keyring such as servers and containers.
+//
+// The passphrase is stretched with scrypt into the key of a NaCl secretbox,
+// which seals the secrets as a whole.
+type FileSecretStore struct {
+	// Path of the encrypted file.
+	Path string
+
+	// Passphrase returns the passphrase of the file. It's called the first
+	// time the file is read or written.
+	Passphrase func() (string, error)
+
+	mu   sync.Mutex
+	salt []byte
+	key  *[32]byte
+}
+
+// secretsFile is the on-disk format of a FileSecretStore.
+type secretsFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// secrets are the plaintext contents of a FileSecretStore, by namespace and key.
+type secrets map[string]map[string]string
+
+// Set sets the given key/value pair with the given namespace.
+func (f *FileSecretStore) Set(namespace, key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	if s[namespace] == nil {
+		s[namespace] = map[string]string{}
+	}
+	s[namespace][key] = value
+
+	return f.write(s)
+}
+
+// Get gets a value for the given namespace and key.
+func (f *FileSecretStore) Get(namespace, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, err := f.read()
+	if err != nil {
+		return "", err
+	}
+
+	v, ok := s[namespace][key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return v, nil
+}
+
+// Delete deletes a value for the given namespace and key.
+func (f *FileSecretStore) Delete(namespace, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := s[namespace][key]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(s[namespace], key)
+
+	return f.write(s)
+}
+
+func (f *FileSecretStore) read() (secrets, error) {
+	buf, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return secrets{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file secretsFile
+	if err := json.Unmarshal(buf, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse the secrets file: %w", err)
+	}
+	if file.Version != secretsFileVersion {
+		return nil, fmt.Errorf("unsupported secrets file version: %d", file.Version)
+	}
+	if len(file.Nonce) != 24 {
+		return nil, errors.New("unable to parse the secrets file: invalid nonce")
+	}
+
+	if err := f.unlock(file.Salt); err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], file.Nonce)
+
+	plain, ok := secretbox.Open(nil, file.Data, &nonce, f.key)
+	if !ok {
+		// Forget the key so the passphrase isn't assumed to be right on
+		// the next call.
+		f.key = nil
+		return nil, ErrWrongPassphrase
+	}
+
+	s := secrets{}
+	if err := json.Unmarshal(plain, &s); err != nil {
+		return nil, fmt.Errorf("unable to parse the secrets file: %w", err)
+	}
+	return s, nil
+}
+
+func (f *FileSecretStore) write(s secrets) error {
+	if err := f.unlock(f.salt); err != nil {
+		return err
+	}
+
+	plain, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	var nonce [24]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(secretsFile{
+		Version: secretsFileVersion,
+		Salt:    f.salt,
+		Nonce:   nonce[:],
+		Data:    secretbox.Seal(nil, plain, &nonce, f.key),
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(f.Path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a failed write doesn't leave
+	// a truncated file behind.
+	tmp, err := ioutil.TempFile(dir, filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// unlock derives the key of the file from its passphrase. A new salt is
+// generated when the file doesn't exist yet.
+func (f *FileSecretStore) unlock(salt []byte) error {
+	if f.key != nil && string(f.salt) == string(salt) {
+		return nil
+	}
+
+	if len(salt) == 0 {
+		salt = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return err
+		}
+	}
+
+	if f.Passphrase == nil {
+		return errors.New("no passphrase for the secrets file")
+	}
+	passphrase, err := f.Passphrase()
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return errors.New("the passphrase of the secrets file can't be empty")
+	}
+
+	k, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return err
+	}
+
+	var key [32]byte
+	copy(key[:], k)
+
+	f.salt = salt
+	f.key = &key
+	return nil
+}
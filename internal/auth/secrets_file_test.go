@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSecretStore(t *testing.T) {
+	passphrase := func(p string) func() (string, error) {
+		return func() (string, error) { return p, nil }
+	}
+
+	t.Run("fail: not found", func(t *testing.T) {
+		fs := &FileSecretStore{Path: filepath.Join(t.TempDir(), "secrets.enc"), Passphrase: passphrase("hunter2")}
+
+		if _, err := fs.Get("mynamespace", "foo"); err != ErrSecretNotFound {
+			t.Fatalf("wanted error: %v, got: %v", ErrSecretNotFound, err)
+		}
+	})
+
+	t.Run("succeed: set, get and delete secrets", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secrets.enc")
+		fs := &FileSecretStore{Path: path, Passphrase: passphrase("hunter2")}
+
+		if err := fs.Set("mynamespace", "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+		if err := fs.Set("mynamespace", "baz", "qux"); err != nil {
+			t.Fatal(err)
+		}
+
+		// read it back with a fresh store, as another run of the cli would:
+		fs = &FileSecretStore{Path: path, Passphrase: passphrase("hunter2")}
+		v, err := fs.Get("mynamespace", "foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := v, "bar"; got != want {
+			t.Fatalf("wanted secret: %v, got: %v", want, got)
+		}
+
+		if err := fs.Delete("mynamespace", "foo"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fs.Get("mynamespace", "foo"); err != ErrSecretNotFound {
+			t.Fatalf("wanted error: %v, got: %v", ErrSecretNotFound, err)
+		}
+		if v, _ := fs.Get("mynamespace", "baz"); v != "qux" {
+			t.Fatalf("wanted secret: qux, got: %v", v)
+		}
+	})
+
+	t.Run("succeed: secrets are encrypted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secrets.enc")
+		fs := &FileSecretStore{Path: path, Passphrase: passphrase("hunter2")}
+
+		if err := fs.Set("mynamespace", "foo", "my-refresh-token"); err != nil {
+			t.Fatal(err)
+		}
+
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(buf), "my-refresh-token") {
+			t.Fatal("wanted the secret to be encrypted")
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := info.Mode().Perm(), os.FileMode(0600); got != want {
+			t.Fatalf("wanted mode: %v, got: %v", want, got)
+		}
+	})
+
+	t.Run("fail: wrong passphrase", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secrets.enc")
+
+		fs := &FileSecretStore{Path: path, Passphrase: passphrase("hunter2")}
+		if err := fs.Set("mynamespace", "foo", "bar"); err != nil {
+			t.Fatal(err)
+		}
+
+		fs = &FileSecretStore{Path: path, Passphrase: passphrase("hunter3")}
+		if _, err := fs.Get("mynamespace", "foo"); err != ErrWrongPassphrase {
+			t.Fatalf("wanted error: %v, got: %v", ErrWrongPassphrase, err)
+		}
+	})
+
+	t.Run("fail: empty passphrase", func(t *testing.T) {
+		fs := &FileSecretStore{Path: filepath.Join(t.TempDir(), "secrets.enc"), Passphrase: passphrase("")}
+
+		if err := fs.Set("mynamespace", "foo", "bar"); err == nil {
+			t.Fatal("wanted an error")
+		}
+	})
+}
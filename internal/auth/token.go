@@ -15,6 +15,7 @@ type TokenResponse struct {
 	IDToken     string `json:"id_token"`
 	TokenType   string `json:"token_type"`
 	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
 }
 
 type TokenRetriever struct {
@@ -0,0 +1,23 @@
+package auth0
+
+import "github.com/auth0/go-auth0/management"
+
+type AttackProtectionAPI interface {
+	// GetBreachedPasswordDetection retrieves breached password detection settings.
+	GetBreachedPasswordDetection(opts ...management.RequestOption) (*management.BreachedPasswordDetection, error)
+
+	// UpdateBreachedPasswordDetection updates the breached password detection settings.
+	UpdateBreachedPasswordDetection(bpd *management.BreachedPasswordDetection, opts ...management.RequestOption) error
+
+	// GetBruteForceProtection retrieves the brute force configuration.
+	GetBruteForceProtection(opts ...management.RequestOption) (*management.BruteForceProtection, error)
+
+	// UpdateBruteForceProtection updates the brute force configuration.
+	UpdateBruteForceProtection(bfp *management.BruteForceProtection, opts ...management.RequestOption) error
+
+	// GetSuspiciousIPThrottling retrieves the suspicious IP throttling configuration.
+	GetSuspiciousIPThrottling(opts ...management.RequestOption) (*management.SuspiciousIPThrottling, error)
+
+	// UpdateSuspiciousIPThrottling updates the suspicious IP throttling configuration.
+	UpdateSuspiciousIPThrottling(sit *management.SuspiciousIPThrottling, opts ...management.RequestOption) error
+}
@@ -0,0 +1,10 @@
+package auth0
+
+import "github.com/auth0/go-auth0/management"
+
+type ClientGrantAPI interface {
+	// List all client grants.
+	//
+	// See: https://auth0.com/docs/api/management/v2#!/Client_Grants/get_client_grants
+	List(opts ...management.RequestOption) (gs *management.ClientGrantList, err error)
+}
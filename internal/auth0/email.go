@@ -0,0 +1,25 @@
+package auth0
+
+import "github.com/auth0/go-auth0/management"
+
+type EmailAPI interface {
+	// Create an email provider.
+	//
+	// See: https://auth0.com/docs/api/management/v2#!/Emails/post_provider
+	Create(e *management.Email, opts ...management.RequestOption) (err error)
+
+	// Retrieve email provider details.
+	//
+	// See: https://auth0.com/docs/api/management/v2#!/Emails/get_provider
+	Read(opts ...management.RequestOption) (e *management.Email, err error)
+
+	// Update an email provider.
+	//
+	// See: https://auth0.com/docs/api/management/v2#!/Emails/patch_provider
+	Update(e *management.Email, opts ...management.RequestOption) (err error)
+
+	// Delete the email provider.
+	//
+	// See: https://auth0.com/docs/api/management/v2#!/Emails/delete_provider
+	Delete(opts ...management.RequestOption) (err error)
+}
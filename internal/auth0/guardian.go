@@ -0,0 +1,8 @@
+package auth0
+
+import "github.com/auth0/go-auth0/management"
+
+type MultiFactorAPI interface {
+	// List retrieves all factors.
+	List(opts ...management.RequestOption) (mf []*management.MultiFactor, err error)
+}
@@ -0,0 +1,34 @@
+package auth0
+
+import "github.com/auth0/go-auth0/management"
+
+// LogEvent is a log with the fields management.Log doesn't carry.
+type LogEvent struct {
+	*management.Log
+
+	UserName     *string `json:"user_name,omitempty"`
+	Connection   *string `json:"connection,omitempty"`
+	ConnectionID *string `json:"connection_id,omitempty"`
+	Strategy     *string `json:"strategy,omitempty"`
+	StrategyType *string `json:"strategy_type,omitempty"`
+	Hostname     *string `json:"hostname,omitempty"`
+	UserAgent    *string `json:"user_agent,omitempty"`
+	Audience     *string `json:"audience,omitempty"`
+	Scope        *string `json:"scope,omitempty"`
+	IsMobile     *bool   `json:"isMobile,omitempty"`
+}
+
+type LogEventAPI interface {
+	// Read retrieves the log event identified by id.
+	Read(id string) (*LogEvent, error)
+}
+
+type logEvents struct {
+	m *management.Management
+}
+
+func (l *logEvents) Read(id string) (*LogEvent, error) {
+	e := &LogEvent{}
+	err := l.m.Request("GET", l.m.URI("logs", id), e)
+	return e, err
+}
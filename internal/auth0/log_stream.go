@@ -18,3 +18,43 @@ type LogStreamAPI interface {
 	// Delete a log stream.
 	Delete(id string, opts ...management.RequestOption) (err error)
 }
+
+// LogStreamFilter limits the logs sent to a log stream to a category, e.g.
+// auth.login.fail.
+type LogStreamFilter struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// LogStreamFiltersAPI reads and writes the filters of a log stream, which
+// aren't part of management.LogStream.
+type LogStreamFiltersAPI interface {
+	// Read the filters of a log stream.
+	Read(id string) ([]LogStreamFilter, error)
+
+	// Update replaces the filters of a log stream. No filters means every log
+	// is sent.
+	Update(id string, filters []LogStreamFilter) error
+}
+
+type logStreamFilters struct {
+	m *management.Management
+}
+
+func (l *logStreamFilters) Read(id string) ([]LogStreamFilter, error) {
+	var ls struct {
+		Filters []LogStreamFilter `json:"filters"`
+	}
+	err := l.m.Request("GET", l.m.URI("log-streams", id), &ls)
+	return ls.Filters, err
+}
+
+func (l *logStreamFilters) Update(id string, filters []LogStreamFilter) error {
+	if filters == nil {
+		filters = []LogStreamFilter{}
+	}
+	ls := struct {
+		Filters []LogStreamFilter `json:"filters"`
+	}{filters}
+	return l.m.Request("PATCH", l.m.URI("log-streams", id), &ls)
+}
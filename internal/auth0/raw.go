@@ -0,0 +1,14 @@
+package auth0
+
+import "net/http"
+
+// RawAPI sends requests to any endpoint of the Management API, for the ones
+// that aren't wrapped by the SDK.
+type RawAPI interface {
+	// URI returns the absolute URL of the Management API with any path
+	// segments appended to the end.
+	URI(path ...string) string
+
+	// Do sends a request authenticated with the credentials of the tenant.
+	Do(req *http.Request) (*http.Response, error)
+}
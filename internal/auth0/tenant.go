@@ -4,4 +4,6 @@ import "github.com/auth0/go-auth0/management"
 
 type TenantAPI interface {
 	Read(opts ...management.RequestOption) (t *management.Tenant, err error)
+
+	Update(t *management.Tenant, opts ...management.RequestOption) (err error)
 }
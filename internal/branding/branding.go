@@ -89,26 +89,7 @@ func PreviewCustomTemplate(ctx context.Context, data TemplateData) error {
 func buildRoutes(ctx context.Context, requestTimeout time.Duration, data TemplateData, broadcaster *caster.Caster) *http.ServeMux {
 	router := http.NewServeMux()
 
-	router.HandleFunc("/dynamic/events", func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		changes, _ := broadcaster.Sub(ctx, 1)
-		defer broadcaster.Unsub(changes)
-
-		writeStatus := func(w http.ResponseWriter, code int) {
-			msg := fmt.Sprintf("%d - %s", code, http.StatusText(http.StatusGone))
-			http.Error(w, msg, code)
-		}
-
-		select {
-		case <-ctx.Done():
-			writeStatus(w, http.StatusGone)
-		case <-time.After(requestTimeout):
-			writeStatus(w, http.StatusRequestTimeout)
-		case <-changes:
-			writeStatus(w, http.StatusOK)
-		}
-	})
+	router.HandleFunc("/dynamic/events", changesHandler(requestTimeout, broadcaster))
 
 	// The template file
 	router.HandleFunc("/dynamic/template", func(w http.ResponseWriter, r *http.Request) {
@@ -135,6 +116,31 @@ func buildRoutes(ctx context.Context, requestTimeout time.Duration, data Templat
 	return router
 }
 
+// changesHandler long polls for changes to the previewed file, answering with
+// a 200 as soon as it changes.
+func changesHandler(requestTimeout time.Duration, broadcaster *caster.Caster) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		changes, _ := broadcaster.Sub(ctx, 1)
+		defer broadcaster.Unsub(changes)
+
+		writeStatus := func(w http.ResponseWriter, code int) {
+			msg := fmt.Sprintf("%d - %s", code, http.StatusText(http.StatusGone))
+			http.Error(w, msg, code)
+		}
+
+		select {
+		case <-ctx.Done():
+			writeStatus(w, http.StatusGone)
+		case <-time.After(requestTimeout):
+			writeStatus(w, http.StatusRequestTimeout)
+		case <-changes:
+			writeStatus(w, http.StatusOK)
+		}
+	}
+}
+
 func broadcastCustomTemplateChanges(ctx context.Context, filename string) (*caster.Caster, error) {
 	publisher := caster.New(ctx)
 
@@ -0,0 +1,303 @@
+package branding
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/guiguan/caster"
+	"github.com/pkg/browser"
+)
+
+// EmailData contains everything needed to render an email template the way
+// Auth0 would send it.
+type EmailData struct {
+	// Filename is the Liquid body of the template. It's read again on every
+	// render so changes show up in the preview as soon as they're saved.
+	Filename  string
+	Template  string
+	From      string
+	Subject   string
+	Variables map[string]interface{}
+
+	// SMTPAddr is the address of a local SMTP sink that every render is sent
+	// to, e.g. MailHog or Mailpit. Nothing is sent when empty.
+	SMTPAddr string
+	To       string
+}
+
+// RenderedEmail is the result of rendering an email template.
+type RenderedEmail struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// SampleEmailVariables returns the variables Auth0 exposes to email templates
+// populated with sample data for the given tenant.
+func SampleEmailVariables(tenantName, domain string) map[string]interface{} {
+	return map[string]interface{}{
+		"application": map[string]interface{}{
+			"name":     "My App",
+			"clientID": "aBcD1234eFgH5678iJkL9012mNoP3456",
+		},
+		"connection": map[string]interface{}{
+			"name": "Username-Password-Authentication",
+		},
+		"user": map[string]interface{}{
+			"user_id":        "auth0|5f7c8ec7c33c6c004bbafe82",
+			"email":          "jane.doe@example.com",
+			"email_verified": false,
+			"name":           "Jane Doe",
+			"given_name":     "Jane",
+			"family_name":    "Doe",
+			"nickname":       "jane.doe",
+			"picture":        "https://s.gravatar.com/avatar/00000000000000000000000000000000?s=480&d=mp",
+			"app_metadata":   map[string]interface{}{},
+			"user_metadata":  map[string]interface{}{},
+		},
+		"tenant":        tenantName,
+		"friendly_name": tenantName,
+		"support_email": fmt.Sprintf("support@%s", domain),
+		"support_url":   fmt.Sprintf("https://%s/support", domain),
+		"url":           fmt.Sprintf("https://%s/u/email-verification?ticket=sample-ticket#", domain),
+		"code":          "123456",
+		"locale":        "en",
+	}
+}
+
+// RenderEmail renders the subject, sender and body of an email template.
+func RenderEmail(data EmailData) (*RenderedEmail, error) {
+	body, err := ioutil.ReadFile(data.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var email RenderedEmail
+	fields := []struct {
+		source string
+		target *string
+	}{
+		{data.From, &email.From},
+		{data.Subject, &email.Subject},
+		{string(body), &email.Body},
+	}
+
+	for _, f := range fields {
+		tmpl, err := ParseLiquid(f.source)
+		if err != nil {
+			return nil, err
+		}
+
+		if *f.target, err = tmpl.Render(data.Variables, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	email.To = data.To
+	return &email, nil
+}
+
+// SendEmail delivers a rendered email to an SMTP server without
+// authentication, which is all a local SMTP sink needs.
+func SendEmail(addr string, email *RenderedEmail) error {
+	if email.From == "" || email.To == "" {
+		return fmt.Errorf("a sender and a recipient are required to send an email")
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", email.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", email.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
+	msg.WriteString(email.Body)
+
+	return smtp.SendMail(addr, nil, email.From, []string{email.To}, msg.Bytes())
+}
+
+// PreviewEmailTemplate serves the rendered email template on a local server
+// and opens it in the browser. The page is reloaded whenever the template
+// file changes, and every render is delivered to the SMTP sink if one is set.
+// The sent callback is called with the outcome of each delivery.
+func PreviewEmailTemplate(ctx context.Context, data EmailData, sent func(error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	// Long polling waiting for file changes
+	broadcaster, err := broadcastCustomTemplateChanges(ctx, data.Filename)
+	if err != nil {
+		return err
+	}
+
+	if data.SMTPAddr != "" {
+		changes, _ := broadcaster.Sub(ctx, 1)
+		go func() {
+			defer broadcaster.Unsub(changes)
+			for {
+				sent(sendRenderedEmail(data))
+
+				// Editors usually fire several events per save, so wait for
+				// them to settle before sending again.
+				select {
+				case <-ctx.Done():
+					return
+				case <-changes:
+				}
+				time.Sleep(250 * time.Millisecond)
+				drain(changes)
+			}
+		}()
+	}
+
+	requestTimeout := 10 * time.Minute
+	server := &http.Server{
+		Handler:      buildEmailRoutes(ctx, requestTimeout, data, broadcaster),
+		ReadTimeout:  requestTimeout + 1*time.Minute,
+		WriteTimeout: requestTimeout + 1*time.Minute,
+	}
+	defer server.Close()
+
+	go func() {
+		if err = server.Serve(listener); err != http.ErrServerClosed {
+			cancel()
+		}
+	}()
+
+	u := &url.URL{Scheme: "http", Host: listener.Addr().String(), Path: "/"}
+	if err := browser.OpenURL(u.String()); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func sendRenderedEmail(data EmailData) error {
+	email, err := RenderEmail(data)
+	if err != nil {
+		return err
+	}
+	return SendEmail(data.SMTPAddr, email)
+}
+
+func drain(ch chan interface{}) {
+	for {
+		select {
+		case <-ch:
+		default:
+			return
+		}
+	}
+}
+
+func buildEmailRoutes(ctx context.Context, requestTimeout time.Duration, data EmailData, broadcaster *caster.Caster) *http.ServeMux {
+	router := http.NewServeMux()
+
+	router.HandleFunc("/dynamic/events", changesHandler(requestTimeout, broadcaster))
+
+	// The envelope around the rendered email, which reloads itself whenever
+	// the template changes.
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		page := emailPreviewPage{Template: data.Template, To: data.To}
+		email, err := RenderEmail(data)
+		if err != nil {
+			page.Error = err.Error()
+		} else {
+			page.From = email.From
+			page.Subject = email.Subject
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := emailPreviewTemplate.Execute(w, page); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	// The rendered email body, framed by the envelope.
+	router.HandleFunc("/dynamic/email", func(w http.ResponseWriter, r *http.Request) {
+		email, err := RenderEmail(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(email.Body))
+	})
+
+	return router
+}
+
+type emailPreviewPage struct {
+	Template string
+	From     string
+	To       string
+	Subject  string
+	Error    string
+}
+
+var emailPreviewTemplate = template.Must(template.New("email-preview").Parse(strings.TrimSpace(`
+<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <title>{{.Template}} | Email preview</title>
+    <style>
+      body { margin: 0; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; background: #f4f4f5; }
+      header { padding: 16px 24px; background: #fff; border-bottom: 1px solid #e3e4e6; font-size: 14px; }
+      header dl { display: grid; grid-template-columns: max-content auto; gap: 4px 12px; margin: 0; }
+      header dt { color: #65676e; }
+      header dd { margin: 0; }
+      .error { padding: 24px; color: #d03c38; white-space: pre-wrap; font-family: monospace; }
+      iframe { display: block; width: 100%; height: calc(100vh - 110px); border: 0; background: #fff; }
+    </style>
+  </head>
+  <body>
+    <header>
+      <dl>
+        <dt>Template</dt><dd>{{.Template}}</dd>
+        <dt>From</dt><dd>{{.From}}</dd>
+        <dt>To</dt><dd>{{.To}}</dd>
+        <dt>Subject</dt><dd>{{.Subject}}</dd>
+      </dl>
+    </header>
+    {{if .Error}}<div class="error">{{.Error}}</div>{{else}}<iframe src="/dynamic/email"></iframe>{{end}}
+    <script>
+      (function poll() {
+        fetch("/dynamic/events").then(function (res) {
+          if (res.status === 200) {
+            window.location.reload();
+          } else {
+            poll();
+          }
+        }).catch(function () {
+          setTimeout(poll, 1000);
+        });
+      })();
+    </script>
+  </body>
+</html>
+`)))
@@ -0,0 +1,63 @@
+package branding
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guiguan/caster"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEmail(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "welcome.html")
+	body := `<p>Hi {{ user.given_name }}, welcome to {{ application.name }}!</p>{% if user.email_verified %}verified{% endif %}`
+	assert.NoError(t, ioutil.WriteFile(filename, []byte(body), 0600))
+
+	email, err := RenderEmail(EmailData{
+		Filename:  filename,
+		From:      "{{ friendly_name }} <{{ support_email }}>",
+		Subject:   "Welcome to {{ friendly_name }}",
+		Variables: SampleEmailVariables("Travel0", "travel0.auth0.com"),
+		To:        "jane.doe@example.com",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Travel0 <support@travel0.auth0.com>", email.From)
+	assert.Equal(t, "Welcome to Travel0", email.Subject)
+	assert.Equal(t, "<p>Hi Jane, welcome to My App!</p>", email.Body)
+
+	assert.NoError(t, ioutil.WriteFile(filename, []byte("{% if user.name %}"), 0600))
+	_, err = RenderEmail(EmailData{Filename: filename})
+	assert.Error(t, err)
+}
+
+func TestEmailRoutes(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "welcome.html")
+	assert.NoError(t, ioutil.WriteFile(filename, []byte("<p>Hi {{ user.given_name }}</p>"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	data := EmailData{
+		Filename:  filename,
+		Template:  "welcome",
+		Subject:   "Welcome",
+		Variables: SampleEmailVariables("Travel0", "travel0.auth0.com"),
+	}
+	router := buildEmailRoutes(ctx, time.Second, data, caster.New(ctx))
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), `<iframe src="/dynamic/email">`)
+
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/dynamic/email", nil))
+	assert.Equal(t, "<p>Hi Jane</p>", res.Body.String())
+}
@@ -0,0 +1,666 @@
+package branding
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The Liquid support in this file is limited to what Universal Login page
+// templates and email templates are documented with: output of variables,
+// if/elsif/else/unless conditions, assign and comment, the default, escape,
+// upcase and downcase filters, whitespace control, and the `auth0:*` tags
+// that Universal Login expands server side. It's meant to validate and
+// preview templates offline, not to replace the renderer Auth0 runs, so other
+// filters are passed through and reported by the linter, and other tags are
+// errors.
+
+// LiquidError is a syntax or rendering error, pointing to the template line
+// it originated from.
+type LiquidError struct {
+	Line    int
+	Message string
+}
+
+func (e *LiquidError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// LiquidTag is a tag found in a template.
+type LiquidTag struct {
+	Name string
+	Line int
+}
+
+// LiquidTemplate is a parsed Liquid template.
+type LiquidTemplate struct {
+	nodes []liquidNode
+
+	// CustomTags lists the namespaced tags, e.g. `auth0:widget`, in the
+	// order they appear in the template.
+	CustomTags []LiquidTag
+
+	// UnknownFilters lists the filters used by the template that are
+	// passed through as is when rendering.
+	UnknownFilters []LiquidTag
+}
+
+type liquidTokenKind int
+
+const (
+	liquidText liquidTokenKind = iota
+	liquidOutput
+	liquidTagToken
+)
+
+type liquidToken struct {
+	kind      liquidTokenKind
+	value     string
+	line      int
+	trimLeft  bool
+	trimRight bool
+}
+
+type liquidNode interface {
+	render(ctx *liquidContext, sb *strings.Builder) error
+}
+
+type liquidTextNode struct {
+	text string
+}
+
+type liquidOutputNode struct {
+	expr    string
+	filters []liquidFilter
+	line    int
+}
+
+type liquidFilter struct {
+	name string
+	args []string
+}
+
+type liquidCustomTagNode struct {
+	name string
+	line int
+}
+
+type liquidBranch struct {
+	cond string
+	body []liquidNode
+}
+
+type liquidIfNode struct {
+	branches []liquidBranch
+	elseBody []liquidNode
+	negate   bool
+	line     int
+}
+
+type liquidAssignNode struct {
+	name string
+	expr string
+	line int
+}
+
+var knownLiquidFilters = map[string]bool{
+	"default": true, "downcase": true, "escape": true, "upcase": true,
+}
+
+// ParseLiquid parses the given template.
+func ParseLiquid(source string) (*LiquidTemplate, error) {
+	tokens, err := tokenizeLiquid(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &liquidParser{tokens: tokens, template: &LiquidTemplate{}}
+	nodes, end, err := p.parseUntil(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if end != nil {
+		return nil, &LiquidError{Line: end.line, Message: fmt.Sprintf("unexpected '%s'", end.value)}
+	}
+
+	p.template.nodes = nodes
+	return p.template, nil
+}
+
+// Render renders the template with the given variables. Custom tags are
+// replaced by their value in tags, a custom tag without one is an error.
+func (t *LiquidTemplate) Render(vars map[string]interface{}, tags map[string]string) (string, error) {
+	ctx := &liquidContext{params: vars, vars: map[string]interface{}{}, tags: tags}
+
+	var sb strings.Builder
+	if err := renderLiquidNodes(ctx, &sb, t.nodes); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+func tokenizeLiquid(source string) ([]liquidToken, error) {
+	var tokens []liquidToken
+	line := 1
+
+	for len(source) > 0 {
+		start := liquidTokenStart(source)
+		if start < 0 {
+			tokens = append(tokens, liquidToken{kind: liquidText, value: source, line: line})
+			break
+		}
+
+		if start > 0 {
+			tokens = append(tokens, liquidToken{kind: liquidText, value: source[:start], line: line})
+			line += strings.Count(source[:start], "\n")
+			source = source[start:]
+		}
+
+		kind, closer := liquidOutput, "}}"
+		if source[1] == '%' {
+			kind, closer = liquidTagToken, "%}"
+		}
+
+		end := strings.Index(source[2:], closer)
+		if end < 0 {
+			return nil, &LiquidError{Line: line, Message: fmt.Sprintf("'%s' was not properly terminated with '%s'", source[:2], closer)}
+		}
+
+		inner := source[2 : end+2]
+		token := liquidToken{kind: kind, line: line}
+		if strings.HasPrefix(inner, "-") {
+			token.trimLeft = true
+			inner = inner[1:]
+		}
+		if strings.HasSuffix(inner, "-") {
+			token.trimRight = true
+			inner = inner[:len(inner)-1]
+		}
+		token.value = strings.TrimSpace(inner)
+
+		tokens = append(tokens, token)
+		line += strings.Count(source[:end+4], "\n")
+		source = source[end+4:]
+	}
+
+	// Apply whitespace control to the surrounding text.
+	for i, token := range tokens {
+		if token.kind == liquidText {
+			continue
+		}
+		if token.trimLeft && i > 0 && tokens[i-1].kind == liquidText {
+			tokens[i-1].value = strings.TrimRight(tokens[i-1].value, " \t\r\n")
+		}
+		if token.trimRight && i+1 < len(tokens) && tokens[i+1].kind == liquidText {
+			tokens[i+1].value = strings.TrimLeft(tokens[i+1].value, " \t\r\n")
+		}
+	}
+
+	return tokens, nil
+}
+
+func liquidTokenStart(source string) int {
+	output, tag := strings.Index(source, "{{"), strings.Index(source, "{%")
+	if output < 0 || (tag >= 0 && tag < output) {
+		return tag
+	}
+	return output
+}
+
+type liquidParser struct {
+	tokens   []liquidToken
+	pos      int
+	template *LiquidTemplate
+}
+
+// parseUntil parses nodes until one of the given tags is found, returning
+// that tag token, or until the end of the template. The open token is the
+// block being parsed, if any.
+func (p *liquidParser) parseUntil(open *liquidToken, ends ...string) ([]liquidNode, *liquidToken, error) {
+	var nodes []liquidNode
+
+	for p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		p.pos++
+
+		switch token.kind {
+		case liquidText:
+			nodes = append(nodes, &liquidTextNode{text: token.value})
+
+		case liquidOutput:
+			node, err := p.parseOutput(token)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, node)
+
+		case liquidTagToken:
+			name := liquidTagName(token.value)
+			for _, end := range ends {
+				if name == end {
+					return nodes, &token, nil
+				}
+			}
+
+			node, err := p.parseTag(token, name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if node != nil {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	if open != nil {
+		return nil, nil, unclosedLiquidBlock(open, ends[len(ends)-1])
+	}
+
+	return nodes, nil, nil
+}
+
+func unclosedLiquidBlock(open *liquidToken, end string) error {
+	return &LiquidError{Line: open.line, Message: fmt.Sprintf("'%s' is not closed, missing '%s'", liquidTagName(open.value), end)}
+}
+
+func (p *liquidParser) parseOutput(token liquidToken) (liquidNode, error) {
+	parts := splitLiquidOutsideQuotes(token.value, '|')
+	expr := strings.TrimSpace(parts[0])
+	if expr == "" {
+		return nil, &LiquidError{Line: token.line, Message: "empty output tag"}
+	}
+
+	node := &liquidOutputNode{expr: expr, line: token.line}
+	for _, f := range parts[1:] {
+		filter := parseLiquidFilter(f)
+		if filter.name == "" {
+			return nil, &LiquidError{Line: token.line, Message: "empty filter"}
+		}
+		if !knownLiquidFilters[filter.name] {
+			p.template.UnknownFilters = append(p.template.UnknownFilters, LiquidTag{Name: filter.name, Line: token.line})
+		}
+		node.filters = append(node.filters, filter)
+	}
+
+	return node, nil
+}
+
+func parseLiquidFilter(f string) liquidFilter {
+	f = strings.TrimSpace(f)
+	filter := liquidFilter{name: f}
+	if i := strings.Index(f, ":"); i >= 0 {
+		filter.name = strings.TrimSpace(f[:i])
+		for _, arg := range splitLiquidOutsideQuotes(f[i+1:], ',') {
+			filter.args = append(filter.args, strings.TrimSpace(arg))
+		}
+	}
+	return filter
+}
+
+func (p *liquidParser) parseTag(token liquidToken, name string) (liquidNode, error) {
+	args := strings.TrimSpace(strings.TrimPrefix(token.value, name))
+
+	switch {
+	case strings.Contains(name, ":"):
+		p.template.CustomTags = append(p.template.CustomTags, LiquidTag{Name: name, Line: token.line})
+		return &liquidCustomTagNode{name: name, line: token.line}, nil
+
+	case name == "if" || name == "unless":
+		return p.parseIf(token, name, args)
+
+	case name == "assign":
+		parts := strings.SplitN(args, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, &LiquidError{Line: token.line, Message: "invalid assign, expected 'assign name = value'"}
+		}
+		return &liquidAssignNode{name: strings.TrimSpace(parts[0]), expr: strings.TrimSpace(parts[1]), line: token.line}, nil
+
+	case name == "comment":
+		return nil, p.skipUntil(&token, "endcomment")
+	}
+
+	return nil, &LiquidError{Line: token.line, Message: fmt.Sprintf("unknown tag '%s'", name)}
+}
+
+// skipUntil consumes tokens until the given tag.
+func (p *liquidParser) skipUntil(open *liquidToken, end string) error {
+	for p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		p.pos++
+
+		if token.kind == liquidTagToken && liquidTagName(token.value) == end {
+			return nil
+		}
+	}
+
+	return unclosedLiquidBlock(open, end)
+}
+
+func (p *liquidParser) parseIf(token liquidToken, name, cond string) (liquidNode, error) {
+	if cond == "" {
+		return nil, &LiquidError{Line: token.line, Message: fmt.Sprintf("'%s' requires a condition", name)}
+	}
+
+	node := &liquidIfNode{negate: name == "unless", line: token.line}
+	endTag := "end" + name
+
+	for {
+		body, end, err := p.parseUntil(&token, "elsif", "else", endTag)
+		if err != nil {
+			return nil, err
+		}
+		node.branches = append(node.branches, liquidBranch{cond: cond, body: body})
+
+		switch liquidTagName(end.value) {
+		case "elsif":
+			cond = strings.TrimSpace(strings.TrimPrefix(end.value, "elsif"))
+			if cond == "" {
+				return nil, &LiquidError{Line: end.line, Message: "'elsif' requires a condition"}
+			}
+			continue
+
+		case "else":
+			node.elseBody, _, err = p.parseUntil(&token, endTag)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return node, nil
+	}
+}
+
+func liquidTagName(value string) string {
+	if fields := strings.Fields(value); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+func splitLiquidOutsideQuotes(s string, sep byte) []string {
+	var (
+		parts []string
+		quote byte
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+type liquidContext struct {
+	params map[string]interface{}
+	vars   map[string]interface{}
+	tags   map[string]string
+}
+
+// lookup resolves a variable, the assigned ones first.
+func (ctx *liquidContext) lookup(name string) interface{} {
+	if v, ok := ctx.vars[name]; ok {
+		return v
+	}
+	return ctx.params[name]
+}
+
+func renderLiquidNodes(ctx *liquidContext, sb *strings.Builder, nodes []liquidNode) error {
+	for _, n := range nodes {
+		if err := n.render(ctx, sb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *liquidTextNode) render(ctx *liquidContext, sb *strings.Builder) error {
+	sb.WriteString(n.text)
+	return nil
+}
+
+func (n *liquidOutputNode) render(ctx *liquidContext, sb *strings.Builder) error {
+	v := ctx.evaluate(n.expr)
+	for _, f := range n.filters {
+		v = ctx.applyFilter(v, f)
+	}
+	sb.WriteString(liquidString(v))
+	return nil
+}
+
+func (n *liquidCustomTagNode) render(ctx *liquidContext, sb *strings.Builder) error {
+	v, ok := ctx.tags[n.name]
+	if !ok {
+		return &LiquidError{Line: n.line, Message: fmt.Sprintf("unsupported tag '%s'", n.name)}
+	}
+	sb.WriteString(v)
+	return nil
+}
+
+func (n *liquidIfNode) render(ctx *liquidContext, sb *strings.Builder) error {
+	for _, b := range n.branches {
+		if ctx.condition(b.cond) != n.negate {
+			return renderLiquidNodes(ctx, sb, b.body)
+		}
+	}
+	return renderLiquidNodes(ctx, sb, n.elseBody)
+}
+
+func (n *liquidAssignNode) render(ctx *liquidContext, sb *strings.Builder) error {
+	parts := splitLiquidOutsideQuotes(n.expr, '|')
+	v := ctx.evaluate(strings.TrimSpace(parts[0]))
+	for _, f := range parts[1:] {
+		v = ctx.applyFilter(v, parseLiquidFilter(f))
+	}
+
+	ctx.vars[n.name] = v
+	return nil
+}
+
+// condition evaluates a condition. Like Liquid, `and`/`or` have no
+// precedence and are evaluated from right to left.
+func (ctx *liquidContext) condition(expr string) bool {
+	fields := splitLiquidFields(expr)
+	for i := len(fields) - 1; i >= 0; i-- {
+		switch fields[i] {
+		case "or":
+			return ctx.condition(strings.Join(fields[:i], " ")) || ctx.condition(strings.Join(fields[i+1:], " "))
+		case "and":
+			return ctx.condition(strings.Join(fields[:i], " ")) && ctx.condition(strings.Join(fields[i+1:], " "))
+		}
+	}
+
+	if len(fields) == 3 {
+		return ctx.compare(ctx.evaluate(fields[0]), fields[1], ctx.evaluate(fields[2]))
+	}
+
+	return liquidTruthy(ctx.evaluate(strings.Join(fields, " ")))
+}
+
+func (ctx *liquidContext) compare(left interface{}, op string, right interface{}) bool {
+	switch op {
+	case "==":
+		return liquidEqual(left, right)
+	case "!=", "<>":
+		return !liquidEqual(left, right)
+	case "contains":
+		if s, ok := left.(string); ok {
+			return strings.Contains(s, liquidString(right))
+		}
+		for _, item := range liquidItems(left) {
+			if liquidEqual(item, right) {
+				return true
+			}
+		}
+		return false
+	}
+
+	l, lok := liquidNumber(left)
+	r, rok := liquidNumber(right)
+	if !lok || !rok {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return l < r
+	case ">":
+		return l > r
+	case "<=":
+		return l <= r
+	case ">=":
+		return l >= r
+	}
+
+	return false
+}
+
+// evaluate resolves a literal or a variable path like `user.name`.
+func (ctx *liquidContext) evaluate(expr string) interface{} {
+	expr = strings.TrimSpace(expr)
+
+	switch {
+	case expr == "" || expr == "nil" || expr == "null":
+		return nil
+	case expr == "true":
+		return true
+	case expr == "false":
+		return false
+	case expr == "empty" || expr == "blank":
+		return ""
+	case len(expr) >= 2 && (expr[0] == '"' || expr[0] == '\'') && expr[len(expr)-1] == expr[0]:
+		return expr[1 : len(expr)-1]
+	}
+
+	if n, err := strconv.ParseFloat(expr, 64); err == nil {
+		return n
+	}
+
+	path := strings.Split(expr, ".")
+	v := ctx.lookup(path[0])
+	for _, key := range path[1:] {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+
+	return v
+}
+
+func (ctx *liquidContext) applyFilter(v interface{}, f liquidFilter) interface{} {
+	arg := func(i int) interface{} {
+		if i < len(f.args) {
+			return ctx.evaluate(f.args[i])
+		}
+		return nil
+	}
+
+	switch f.name {
+	case "default":
+		if !liquidTruthy(v) || v == "" {
+			return arg(0)
+		}
+		return v
+	case "downcase":
+		return strings.ToLower(liquidString(v))
+	case "upcase":
+		return strings.ToUpper(liquidString(v))
+	case "escape":
+		return html.EscapeString(liquidString(v))
+	}
+
+	return v
+}
+
+func splitLiquidFields(expr string) []string {
+	var fields []string
+	for _, part := range splitLiquidOutsideQuotes(expr, ' ') {
+		if part != "" {
+			fields = append(fields, part)
+		}
+	}
+	return fields
+}
+
+func liquidTruthy(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	if b, ok := v.(bool); ok {
+		return b
+	}
+	return true
+}
+
+func liquidEqual(a, b interface{}) bool {
+	if an, ok := liquidNumber(a); ok {
+		if bn, ok := liquidNumber(b); ok {
+			return an == bn
+		}
+	}
+
+	// `empty` compares equal to empty strings and lists.
+	if s, ok := b.(string); ok && s == "" && a != nil {
+		if _, isString := a.(string); !isString {
+			return len(liquidItems(a)) == 0
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func liquidNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func liquidItems(v interface{}) []interface{} {
+	switch items := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return items
+	case []string:
+		var res []interface{}
+		for _, item := range items {
+			res = append(res, item)
+		}
+		return res
+	}
+	return nil
+}
+
+func liquidString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
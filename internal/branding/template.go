@@ -0,0 +1,226 @@
+package branding
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+const (
+	// MaxTemplateSize is the largest page template accepted by Auth0.
+	MaxTemplateSize = 100 * 1024
+
+	// DefaultPrompt is the Universal Login prompt templates are rendered for
+	// unless told otherwise.
+	DefaultPrompt = "login"
+
+	LintError   = "error"
+	LintWarning = "warning"
+
+	headTag   = "auth0:head"
+	widgetTag = "auth0:widget"
+)
+
+// LintIssue is a problem found in a page template.
+type LintIssue struct {
+	Severity string `json:"severity"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+}
+
+// LintTemplate checks a Universal Login page template for the problems that
+// would make Auth0 reject it, or that would break the login page.
+func LintTemplate(body string) []LintIssue {
+	var issues []LintIssue
+
+	if len(body) > MaxTemplateSize {
+		issues = append(issues, LintIssue{
+			Severity: LintError,
+			Message:  fmt.Sprintf("template is %d bytes, the maximum allowed is %d bytes", len(body), MaxTemplateSize),
+		})
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return append(issues, LintIssue{Severity: LintError, Message: "template is empty"})
+	}
+
+	tmpl, err := ParseLiquid(body)
+	if err != nil {
+		issue := LintIssue{Severity: LintError, Message: err.Error()}
+		if lerr, ok := err.(*LiquidError); ok {
+			issue.Line = lerr.Line
+			issue.Message = lerr.Message
+		}
+		return append(issues, issue)
+	}
+
+	found := map[string]bool{}
+	for _, tag := range tmpl.CustomTags {
+		switch tag.Name {
+		case headTag, widgetTag:
+			found[tag.Name] = true
+		default:
+			issues = append(issues, LintIssue{
+				Severity: LintError,
+				Line:     tag.Line,
+				Message:  fmt.Sprintf("unsupported tag '%s'", tag.Name),
+			})
+		}
+	}
+
+	for _, name := range []string{headTag, widgetTag} {
+		if !found[name] {
+			issues = append(issues, LintIssue{
+				Severity: LintError,
+				Message:  fmt.Sprintf("missing required tag '{%%- %s -%%}'", name),
+			})
+		}
+	}
+
+	if found[headTag] && !insideElement(body, "head", headTag) {
+		issues = append(issues, LintIssue{
+			Severity: LintWarning,
+			Line:     tagLine(tmpl, headTag),
+			Message:  fmt.Sprintf("'%s' should be placed inside the <head> element", headTag),
+		})
+	}
+
+	if found[widgetTag] && !insideElement(body, "body", widgetTag) {
+		issues = append(issues, LintIssue{
+			Severity: LintWarning,
+			Line:     tagLine(tmpl, widgetTag),
+			Message:  fmt.Sprintf("'%s' should be placed inside the <body> element", widgetTag),
+		})
+	}
+
+	for _, f := range tmpl.UnknownFilters {
+		issues = append(issues, LintIssue{
+			Severity: LintWarning,
+			Line:     f.Line,
+			Message:  fmt.Sprintf("filter '%s' can't be checked offline", f.Name),
+		})
+	}
+
+	return issues
+}
+
+// HasLintErrors is true if any of the issues would make Auth0 reject the
+// template.
+func HasLintErrors(issues []LintIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+func insideElement(body, element, tag string) bool {
+	lower := strings.ToLower(body)
+	start := strings.Index(lower, "<"+element)
+	end := strings.Index(lower, "</"+element+">")
+	pos := strings.Index(body, tag)
+
+	return start >= 0 && end >= 0 && start < pos && pos < end
+}
+
+func tagLine(tmpl *LiquidTemplate, name string) int {
+	for _, tag := range tmpl.CustomTags {
+		if tag.Name == name {
+			return tag.Line
+		}
+	}
+	return 0
+}
+
+// RenderTemplate renders a page template to a static HTML page for the given
+// prompt, replacing the Auth0 tags with a static approximation of the login
+// widget styled with the tenant branding.
+func RenderTemplate(data TemplateData, prompt string) (string, error) {
+	tmpl, err := ParseLiquid(data.Body)
+	if err != nil {
+		return "", err
+	}
+
+	vars := templateVariables(data, prompt)
+
+	var head, widget bytes.Buffer
+	if err := staticHeadTemplate.Execute(&head, vars); err != nil {
+		return "", err
+	}
+	if err := staticWidgetTemplate.Execute(&widget, vars); err != nil {
+		return "", err
+	}
+
+	return tmpl.Render(vars, map[string]string{
+		headTag:   head.String(),
+		widgetTag: widget.String(),
+	})
+}
+
+// templateVariables mirrors the variables Universal Login exposes to page
+// templates.
+func templateVariables(data TemplateData, prompt string) map[string]interface{} {
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+
+	application := map[string]interface{}{
+		"name":     data.TenantName,
+		"logo_url": data.LogoURL,
+	}
+	if len(data.Clients) > 0 {
+		application["id"] = data.Clients[0].ID
+		application["name"] = data.Clients[0].Name
+		if data.Clients[0].LogoURL != "" {
+			application["logo_url"] = data.Clients[0].LogoURL
+		}
+	}
+
+	return map[string]interface{}{
+		"locale":      "en",
+		"application": application,
+		"tenant": map[string]interface{}{
+			"friendly_name": data.TenantName,
+		},
+		"branding": map[string]interface{}{
+			"logo_url": data.LogoURL,
+			"colors": map[string]interface{}{
+				"primary":         data.PrimaryColor,
+				"page_background": data.BackgroundColor,
+			},
+		},
+		"prompt": map[string]interface{}{
+			"name": prompt,
+			"screen": map[string]interface{}{
+				"name": prompt,
+				"texts": map[string]interface{}{
+					"pageTitle":   fmt.Sprintf("Log in | %s", data.TenantName),
+					"title":       "Welcome",
+					"description": fmt.Sprintf("Log in to %s to continue.", data.TenantName),
+				},
+			},
+		},
+	}
+}
+
+var staticHeadTemplate = template.Must(template.New("head").Parse(`<meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <style>
+      body { margin: 0; min-height: 100vh; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; background-color: {{.branding.colors.page_background}}; }
+      ._widget-auto-layout { display: flex; align-items: center; justify-content: center; }
+      .auth0-widget { width: 400px; padding: 40px; box-sizing: border-box; background: #fff; border-radius: 5px; box-shadow: 0 12px 40px rgba(0,0,0,.12); text-align: center; }
+      .auth0-widget img { max-height: 52px; }
+      .auth0-widget input { display: block; width: 100%; margin: 12px 0; padding: 12px; box-sizing: border-box; border: 1px solid #c9cace; border-radius: 3px; }
+      .auth0-widget button { width: 100%; margin-top: 12px; padding: 12px; border: 0; border-radius: 3px; color: #fff; background-color: {{.branding.colors.primary}}; }
+    </style>`))
+
+var staticWidgetTemplate = template.Must(template.New("widget").Parse(`<main class="auth0-widget" data-prompt="{{.prompt.name}}">
+      <img src="{{.application.logo_url}}" alt="{{.application.name}}">
+      <h1>{{.prompt.screen.texts.title}}</h1>
+      <p>{{.prompt.screen.texts.description}}</p>
+      <input type="email" placeholder="Email address" disabled>
+      <input type="password" placeholder="Password" disabled>
+      <button type="button" disabled>Continue</button>
+    </main>`))
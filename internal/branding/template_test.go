@@ -0,0 +1,109 @@
+package branding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiquidRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"output", "Hello {{ user.name }}!", "Hello Jane!"},
+		{"filters", "{{ user.name | upcase }} {{ 'A&B' | downcase | escape }}", "JANE a&amp;b"},
+		{"default", "{{ user.nickname | default: 'friend' }}", "friend"},
+		{"if/else", "{% if user.name == 'Jane' %}hi{% else %}bye{% endif %}", "hi"},
+		{"elsif", "{% if user.age > 40 %}a{% elsif user.age > 30 %}b{% else %}c{% endif %}", "b"},
+		{"unless", "{% unless user.blocked %}welcome{% endunless %}", "welcome"},
+		{"and/or", "{% if user.blocked or user.name == 'Jane' and user.age == 35 %}yes{% endif %}", "yes"},
+		{"contains", "{% if user.roles contains 'admin' %}admin{% endif %}", "admin"},
+		{"assign", "{% assign dir = user.dir | default: 'auto' %}{{ dir }}", "auto"},
+		{"comment", "{% comment %}{{ secret }}{% endcomment %}", ""},
+		{"whitespace control", "a   {%- if true -%}   b   {%- endif -%}   c", "abc"},
+	}
+
+	vars := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":    "Jane",
+			"age":     35,
+			"blocked": false,
+			"roles":   []interface{}{"admin", "user"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := ParseLiquid(test.template)
+			assert.NoError(t, err)
+
+			got, err := tmpl.Render(vars, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestLiquidParseErrors(t *testing.T) {
+	tests := []struct {
+		template string
+		line     int
+	}{
+		{"{% if x %}\nno end", 1},
+		{"ok\n{% endif %}", 2},
+		{"{{ x", 1},
+		{"\n\n{% bogus %}", 3},
+		{"{% for r in roles %}{{ r }}{% endfor %}", 1},
+	}
+
+	for _, test := range tests {
+		_, err := ParseLiquid(test.template)
+		if assert.Error(t, err, test.template) {
+			assert.Equal(t, test.line, err.(*LiquidError).Line, test.template)
+		}
+	}
+}
+
+func TestLintTemplate(t *testing.T) {
+	t.Run("embedded templates are valid", func(t *testing.T) {
+		for _, body := range []string{DefaultTemplate, FooterTemplate, ImageTemplate} {
+			assert.Empty(t, LintTemplate(body))
+		}
+	})
+
+	t.Run("missing widget", func(t *testing.T) {
+		issues := LintTemplate("<html><head>{%- auth0:head -%}</head><body></body></html>")
+		assert.True(t, HasLintErrors(issues))
+		assert.Contains(t, issues[0].Message, "auth0:widget")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		body := DefaultTemplate + "<!--" + strings.Repeat("x", MaxTemplateSize) + "-->"
+		assert.True(t, HasLintErrors(LintTemplate(body)))
+	})
+
+	t.Run("misplaced head", func(t *testing.T) {
+		issues := LintTemplate("<html><head></head><body>{%- auth0:head -%}{%- auth0:widget -%}</body></html>")
+		assert.False(t, HasLintErrors(issues))
+		assert.Len(t, issues, 1)
+	})
+}
+
+func TestRenderTemplate(t *testing.T) {
+	page, err := RenderTemplate(TemplateData{
+		PrimaryColor:    "#0059d6",
+		BackgroundColor: "#000000",
+		LogoURL:         "https://example.com/logo.png",
+		TenantName:      "Travel0",
+		Body:            FooterTemplate,
+	}, "")
+
+	assert.NoError(t, err)
+	assert.Contains(t, page, "<title>Log in | Travel0</title>")
+	assert.Contains(t, page, `data-prompt="login"`)
+	assert.Contains(t, page, "background-color: #0059d6")
+	assert.NotContains(t, page, "auth0:")
+}
@@ -0,0 +1,381 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/spf13/cobra"
+)
+
+const (
+	apiBasePath = "/api/v2/"
+
+	// apiPageSize is the largest page size the Management API allows.
+	apiPageSize = 100
+)
+
+var (
+	apiMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+	apiData = Flag{
+		Name:      "Data",
+		LongForm:  "data",
+		ShortForm: "d",
+		Help:      "JSON body of the request. Use @file to read it from a file, or @- to read it from stdin.",
+	}
+
+	apiPaginate = Flag{
+		Name:     "Paginate",
+		LongForm: "paginate",
+		Help:     "Fetch all the pages of a list, and output them as a single list.",
+	}
+
+	apiDryRun = Flag{
+		Name:     "Dry Run",
+		LongForm: "dry-run",
+		Help:     "Print the equivalent curl command rather than sending the request.",
+	}
+)
+
+// apiRequest is a request to an endpoint of the Management API.
+type apiRequest struct {
+	Method string
+	// Path is relative to /api/v2/.
+	Path  string
+	Query url.Values
+	Data  []byte
+}
+
+func apiCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Data     string
+		Query    []string
+		Paginate bool
+		DryRun   bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "api <method> <path>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Call any endpoint of the Management API",
+		Long: `Call any endpoint of the Management API with the session of the tenant,
+e.g. for the ones no command covers yet.
+
+The path is relative to /api/v2/, and the response is output as JSON. See
+https://auth0.com/docs/api/management/v2 for the endpoints.
+
+The curl printed with --dry-run expects the access token in the
+AUTH0_ACCESS_TOKEN env var, so that it isn't leaked to the terminal.`,
+		Example: `auth0 api get tenants/settings
+auth0 api get users --query q=email:"john@example.com" --query fields=user_id,email
+auth0 api get clients --paginate --query is_global=false
+auth0 api post roles --data '{"name": "admin"}'
+auth0 api patch clients/<id> --data @client.json
+auth0 api delete rules/<id> --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := readAPIData(inputs.Data, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			req, err := newAPIRequest(args[0], args[1], inputs.Query, data)
+			if err != nil {
+				return err
+			}
+
+			if inputs.Paginate && req.Method != http.MethodGet {
+				return errors.New("Only GET requests can be paginated")
+			}
+
+			if inputs.DryRun {
+				cli.renderer.Output(req.curl(cli.api.Raw) + "\n")
+				return nil
+			}
+
+			var res interface{}
+			if err := ansi.Waiting(func() error {
+				if inputs.Paginate {
+					res, err = paginateAPI(cmd.Context(), cli.api.Raw, req)
+				} else {
+					res, err = req.send(cmd.Context(), cli.api.Raw)
+				}
+				return err
+			}); err != nil {
+				return err
+			}
+
+			if res != nil {
+				cli.renderer.JSONResult(res)
+			}
+			return nil
+		},
+	}
+
+	apiData.RegisterString(cmd, &inputs.Data, "")
+	cmd.Flags().StringArrayVarP(&inputs.Query, "query", "q", nil, "Query parameter of the request, as key=value. Can be repeated.")
+	apiPaginate.RegisterBool(cmd, &inputs.Paginate, false)
+	apiDryRun.RegisterBool(cmd, &inputs.DryRun, false)
+
+	return cmd
+}
+
+// readAPIData reads the body of the request from the --data flag, which can
+// refer to a file or to stdin.
+func readAPIData(data string, stdin io.Reader) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch {
+	case data == "":
+		return nil, nil
+	case data == "@-":
+		b, err = ioutil.ReadAll(stdin)
+	case strings.HasPrefix(data, "@"):
+		b, err = ioutil.ReadFile(strings.TrimPrefix(data, "@"))
+	default:
+		b = []byte(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read the data: %w", err)
+	}
+
+	if !json.Valid(b) {
+		return nil, errors.New("The data isn't valid JSON")
+	}
+
+	return b, nil
+}
+
+// newAPIRequest validates the method, and splits the path, which can be given
+// with or without the /api/v2/ prefix, or even as a full URL, from its query.
+func newAPIRequest(method, path string, query []string, data []byte) (apiRequest, error) {
+	req := apiRequest{Method: strings.ToUpper(method), Data: data}
+
+	if !containsStr(apiMethods, req.Method) {
+		return apiRequest{}, fmt.Errorf("Invalid method %q, use one of: %s", method, strings.Join(apiMethods, ", "))
+	}
+
+	if req.Method == http.MethodGet && data != nil {
+		return apiRequest{}, errors.New("GET requests can't have data")
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return apiRequest{}, fmt.Errorf("Invalid path %q: %w", path, err)
+	}
+
+	req.Path = strings.TrimPrefix(strings.TrimPrefix(u.Path, "/"), strings.TrimPrefix(apiBasePath, "/"))
+	req.Query = u.Query()
+
+	for _, q := range query {
+		kv := strings.SplitN(q, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return apiRequest{}, fmt.Errorf("Invalid query %q, use key=value", q)
+		}
+		req.Query.Add(kv[0], kv[1])
+	}
+
+	return req, nil
+}
+
+func (r apiRequest) url(raw auth0.RawAPI) string {
+	u := raw.URI(r.Path)
+	if len(r.Query) > 0 {
+		u += "?" + r.Query.Encode()
+	}
+	return u
+}
+
+// send sends the request, and decodes the response. An empty response decodes
+// to nil.
+func (r apiRequest) send(ctx context.Context, raw auth0.RawAPI) (interface{}, error) {
+	var body io.Reader
+	if r.Data != nil {
+		body = bytes.NewReader(r.Data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.url(raw), body)
+	if err != nil {
+		return nil, err
+	}
+	if r.Data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := raw.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", r.Method, r.Path, err)
+	}
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", r.Method, r.Path, err)
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(b, &apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(b))
+		}
+		return nil, fmt.Errorf("%s %s failed with %d %s: %s", r.Method, r.Path, res.StatusCode, http.StatusText(res.StatusCode), apiErr.Message)
+	}
+
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, nil
+	}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		// Not every endpoint responds with JSON.
+		return string(b), nil
+	}
+
+	return v, nil
+}
+
+// paginateAPI fetches all the pages of a list. Lists are paginated with page
+// and per_page, or with from and take for the ones using checkpoints, which
+// is the case when either is given.
+func paginateAPI(ctx context.Context, raw auth0.RawAPI, r apiRequest) ([]interface{}, error) {
+	q := url.Values{}
+	for k, v := range r.Query {
+		q[k] = v
+	}
+	r.Query = q
+
+	checkpoint := q.Get("from") != "" || q.Get("take") != ""
+
+	perPage := apiPageSize
+	if !checkpoint {
+		if q.Get("per_page") == "" {
+			q.Set("per_page", strconv.Itoa(apiPageSize))
+		}
+		if n, err := strconv.Atoi(q.Get("per_page")); err == nil && n > 0 {
+			perPage = n
+		}
+		if q.Get("page") == "" {
+			q.Set("page", "0")
+		}
+		q.Set("include_totals", "true")
+	}
+
+	all := []interface{}{}
+	for {
+		res, err := r.send(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := apiPage(res)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s can't be paginated: %w", r.Method, r.Path, err)
+		}
+		all = append(all, page.items...)
+
+		if checkpoint {
+			if page.next == "" || len(page.items) == 0 {
+				return all, nil
+			}
+			q.Set("from", page.next)
+			continue
+		}
+
+		if len(page.items) == 0 || len(page.items) < perPage || (page.total >= 0 && page.start+len(page.items) >= page.total) {
+			return all, nil
+		}
+
+		n, _ := strconv.Atoi(q.Get("page"))
+		q.Set("page", strconv.Itoa(n+1))
+	}
+}
+
+type apiPageResult struct {
+	items []interface{}
+	start int
+	// total is -1 when the response doesn't include it.
+	total int
+	next  string
+}
+
+// apiPage extracts the items from a page, which is either a list or an object
+// wrapping the list along with the pagination details, e.g.
+// {"start": 0, "limit": 50, "total": 120, "users": [...]}.
+func apiPage(res interface{}) (apiPageResult, error) {
+	switch v := res.(type) {
+	case []interface{}:
+		return apiPageResult{items: v, total: -1}, nil
+
+	case map[string]interface{}:
+		p := apiPageResult{total: -1}
+
+		// Several lists would be ambiguous, so look for exactly one.
+		var lists []string
+		for k, f := range v {
+			if _, ok := f.([]interface{}); ok {
+				lists = append(lists, k)
+			}
+		}
+		sort.Strings(lists)
+		if len(lists) != 1 {
+			return apiPageResult{}, errors.New("the response isn't a list")
+		}
+		p.items = v[lists[0]].([]interface{})
+
+		if n, ok := v["start"].(json.Number); ok {
+			start, _ := n.Int64()
+			p.start = int(start)
+		}
+		if n, ok := v["total"].(json.Number); ok {
+			total, _ := n.Int64()
+			p.total = int(total)
+		}
+		if next, ok := v["next"].(string); ok {
+			p.next = next
+		}
+
+		return p, nil
+
+	default:
+		return apiPageResult{}, errors.New("the response isn't a list")
+	}
+}
+
+// curl returns the curl command equivalent to the request.
+func (r apiRequest) curl(raw auth0.RawAPI) string {
+	lines := []string{
+		"curl --request " + r.Method,
+		"--url " + shellQuote(r.url(raw)),
+		`--header "Authorization: Bearer $AUTH0_ACCESS_TOKEN"`,
+	}
+	if r.Data != nil {
+		lines = append(lines,
+			"--header "+shellQuote("Content-Type: application/json"),
+			"--data "+shellQuote(string(r.Data)),
+		)
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// shellQuote quotes a string for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRawAPI struct {
+	url string
+}
+
+func (t *testRawAPI) URI(path ...string) string {
+	return t.url + "/api/v2/" + strings.Join(path, "/")
+}
+
+func (t *testRawAPI) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func TestNewAPIRequest(t *testing.T) {
+	t.Run("normalizes the path", func(t *testing.T) {
+		for _, path := range []string{
+			"users",
+			"/users",
+			"/api/v2/users",
+			"api/v2/users",
+			"https://travel0.auth0.com/api/v2/users",
+		} {
+			req, err := newAPIRequest("get", path, nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, apiRequest{Method: "GET", Path: "users", Query: map[string][]string{}}, req, path)
+		}
+	})
+
+	t.Run("merges the query", func(t *testing.T) {
+		req, err := newAPIRequest("GET", "users?fields=email", []string{"q=email:a=b", "fields=name"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email", "name"}, req.Query["fields"])
+		assert.Equal(t, "email:a=b", req.Query.Get("q"))
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		_, err := newAPIRequest("head", "users", nil, nil)
+		assert.EqualError(t, err, `Invalid method "head", use one of: GET, POST, PUT, PATCH, DELETE`)
+
+		_, err = newAPIRequest("get", "users", []string{"fields"}, nil)
+		assert.EqualError(t, err, `Invalid query "fields", use key=value`)
+
+		_, err = newAPIRequest("get", "users", nil, []byte("{}"))
+		assert.EqualError(t, err, "GET requests can't have data")
+	})
+}
+
+func TestReadAPIData(t *testing.T) {
+	b, err := readAPIData("@-", strings.NewReader(`{"name": "admin"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"name": "admin"}`, string(b))
+
+	b, err = readAPIData("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, b)
+
+	_, err = readAPIData("{", nil)
+	assert.EqualError(t, err, "The data isn't valid JSON")
+}
+
+func TestAPIRequestSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/roles":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": "rol_1", "name": "admin"}`)
+		case "/api/v2/rules/rul_1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"statusCode": 404, "error": "Not Found", "message": "Not found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	raw := &testRawAPI{url: srv.URL}
+
+	res, err := apiRequest{Method: "POST", Path: "roles", Data: []byte(`{"name": "admin"}`)}.send(context.Background(), raw)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "rol_1", "name": "admin"}, res)
+
+	res, err = apiRequest{Method: "DELETE", Path: "rules/rul_1"}.send(context.Background(), raw)
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	_, err = apiRequest{Method: "GET", Path: "nope"}.send(context.Background(), raw)
+	assert.EqualError(t, err, "GET nope failed with 404 Not Found: Not found")
+}
+
+func TestPaginateAPI(t *testing.T) {
+	t.Run("page based", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("include_totals"))
+			assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			var users []string
+			for i := page * 2; i < page*2+2 && i < 5; i++ {
+				users = append(users, fmt.Sprintf(`{"user_id": "%d"}`, i))
+			}
+			fmt.Fprintf(w, `{"start": %d, "limit": 2, "total": 5, "users": [%s]}`, page*2, strings.Join(users, ","))
+		}))
+		defer srv.Close()
+
+		req, err := newAPIRequest("GET", "users", []string{"per_page=2"}, nil)
+		require.NoError(t, err)
+
+		res, err := paginateAPI(context.Background(), &testRawAPI{url: srv.URL}, req)
+		require.NoError(t, err)
+		require.Len(t, res, 5)
+		assert.Equal(t, map[string]interface{}{"user_id": "4"}, res[4])
+	})
+
+	t.Run("checkpoint based", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.Query().Get("page"))
+
+			switch r.URL.Query().Get("from") {
+			case "":
+				fmt.Fprint(w, `{"organizations": [{"id": "org_1"}, {"id": "org_2"}], "next": "abc"}`)
+			case "abc":
+				fmt.Fprint(w, `{"organizations": [{"id": "org_3"}]}`)
+			}
+		}))
+		defer srv.Close()
+
+		req, err := newAPIRequest("GET", "organizations", []string{"take=2"}, nil)
+		require.NoError(t, err)
+
+		res, err := paginateAPI(context.Background(), &testRawAPI{url: srv.URL}, req)
+		require.NoError(t, err)
+		assert.Len(t, res, 3)
+	})
+
+	t.Run("not a list", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"friendly_name": "Travel0"}`)
+		}))
+		defer srv.Close()
+
+		_, err := paginateAPI(context.Background(), &testRawAPI{url: srv.URL}, apiRequest{Method: "GET", Path: "tenants/settings"})
+		assert.EqualError(t, err, "GET tenants/settings can't be paginated: the response isn't a list")
+	})
+}
+
+func TestAPIRequestCurl(t *testing.T) {
+	req, err := newAPIRequest("patch", "clients/abc", []string{"fields=name"}, []byte(`{"name": "it's"}`))
+	require.NoError(t, err)
+
+	want := `curl --request PATCH \
+  --url 'https://travel0.auth0.com/api/v2/clients/abc?fields=name' \
+  --header "Authorization: Bearer $AUTH0_ACCESS_TOKEN" \
+  --header 'Content-Type: application/json' \
+  --data '{"name": "it'\''s"}'`
+	assert.Equal(t, want, req.curl(&testRawAPI{url: "https://travel0.auth0.com"}))
+}
@@ -863,10 +863,11 @@ func (c *cli) appPickerOptions() (pickerOptions, error) {
 	// NOTE(cyx): To keep the contract for this simple, we'll rely on the
 	// implicit knowledge that the default value for the picker is the
 	// first option. With that in mind, we'll use the state in
-	// tenant.DefaultAppID to determine which should be chosen as the
-	// default.
+	// tenant.DefaultAppID, or the app of the active profile, to determine
+	// which should be chosen as the default.
 	var (
 		priorityOpts, opts pickerOptions
+		defaultAppID       = c.defaultAppID(tenant)
 	)
 	for _, c := range list.Clients {
 		// empty type means the default client that we shouldn't display.
@@ -879,7 +880,7 @@ func (c *cli) appPickerOptions() (pickerOptions, error) {
 		opt := pickerOption{value: value, label: label}
 
 		// check if this is currently the default application.
-		if tenant.DefaultAppID == c.GetClientID() {
+		if defaultAppID == c.GetClientID() {
 			priorityOpts = append(priorityOpts, opt)
 		} else {
 			opts = append(opts, opt)
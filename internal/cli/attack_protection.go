@@ -0,0 +1,809 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/auth0-cli/internal/prompt"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	bruteForceProtectionShields      = []string{"block", "user_notification"}
+	bruteForceProtectionModes        = []string{"count_per_identifier_and_ip", "count_per_identifier"}
+	suspiciousIPThrottlingShields    = []string{"block", "admin_notification"}
+	breachedPasswordDetectionShields = []string{"block", "user_notification", "admin_notification"}
+	breachedPasswordFrequencies      = []string{"immediately", "daily", "weekly", "monthly"}
+	breachedPasswordMethods          = []string{"standard", "enhanced"}
+)
+
+// blockedIPsConcurrency is how many IPs are checked at once, to stay within
+// the rate limits of the management API.
+const blockedIPsConcurrency = 8
+
+var (
+	protectionEnabled = Flag{
+		Name:         "Enabled",
+		LongForm:     "enabled",
+		ShortForm:    "e",
+		Help:         "Enable (or disable) the protection.",
+		AlwaysPrompt: true,
+	}
+
+	protectionAllowlist = Flag{
+		Name:     "Allowlist",
+		LongForm: "allowlist",
+		Help:     "Comma-separated list of IP addresses or CIDR ranges the protection doesn't apply to. Use \"\" to clear it.",
+	}
+
+	bfpShields = Flag{
+		Name:     "Shields",
+		LongForm: "shields",
+		Help:     "Comma-separated list of actions taken when the protection is triggered. Possible values: " + strings.Join(bruteForceProtectionShields, ", ") + ".",
+	}
+
+	bfpMode = Flag{
+		Name:     "Mode",
+		LongForm: "mode",
+		Help:     "Whether failed attempts are counted per account and IP, or per account only. Possible values: " + strings.Join(bruteForceProtectionModes, ", ") + ".",
+	}
+
+	bfpMaxAttempts = Flag{
+		Name:     "Max Attempts",
+		LongForm: "max-attempts",
+		Help:     "Number of failed login attempts to the same account before it's blocked.",
+	}
+
+	sitShields = Flag{
+		Name:     "Shields",
+		LongForm: "shields",
+		Help:     "Comma-separated list of actions taken when the protection is triggered. Possible values: " + strings.Join(suspiciousIPThrottlingShields, ", ") + ".",
+	}
+
+	sitLoginMaxAttempts = Flag{
+		Name:     "Login Max Attempts",
+		LongForm: "login-max-attempts",
+		Help:     "Number of failed login attempts from the same IP before it's throttled.",
+	}
+
+	sitLoginRate = Flag{
+		Name:     "Login Rate",
+		LongForm: "login-rate",
+		Help:     "Interval at which a throttled IP is granted a new login attempt, e.g. 15m.",
+	}
+
+	sitSignupMaxAttempts = Flag{
+		Name:     "Signup Max Attempts",
+		LongForm: "signup-max-attempts",
+		Help:     "Number of signup attempts from the same IP before it's throttled.",
+	}
+
+	sitSignupRate = Flag{
+		Name:     "Signup Rate",
+		LongForm: "signup-rate",
+		Help:     "Interval at which a throttled IP is granted a new signup attempt, e.g. 1m.",
+	}
+
+	bpdShields = Flag{
+		Name:     "Shields",
+		LongForm: "shields",
+		Help:     "Comma-separated list of actions taken when a breached password is used. Possible values: " + strings.Join(breachedPasswordDetectionShields, ", ") + ".",
+	}
+
+	bpdAdminNotificationFrequency = Flag{
+		Name:     "Admin Notification Frequency",
+		LongForm: "admin-notification-frequency",
+		Help:     "Comma-separated list of how often admins are notified. Possible values: " + strings.Join(breachedPasswordFrequencies, ", ") + ".",
+	}
+
+	bpdMethod = Flag{
+		Name:     "Method",
+		LongForm: "method",
+		Help:     "How passwords are checked against breaches. Possible values: " + strings.Join(breachedPasswordMethods, ", ") + ".",
+	}
+
+	blockedIPsSince = Flag{
+		Name:     "Since",
+		LongForm: "since",
+		Help:     "How far back to look for blocked IPs in the logs, e.g. 24h.",
+	}
+
+	blockedIPsAll = Flag{
+		Name:     "All",
+		LongForm: "all",
+		Help:     "Unblock all the blocked IPs.",
+	}
+)
+
+func protectionCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "protection",
+		Short: "Manage attack protection",
+		Long:  "Manage the attack protection settings of the tenant and the IPs they block.",
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showProtectionCmd(cli))
+	cmd.AddCommand(bruteForceProtectionCmd(cli))
+	cmd.AddCommand(suspiciousIPThrottlingCmd(cli))
+	cmd.AddCommand(breachedPasswordDetectionCmd(cli))
+	cmd.AddCommand(blockedIPsCmd(cli))
+
+	return cmd
+}
+
+func showProtectionCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Args:  cobra.NoArgs,
+		Short: "Show the attack protection settings",
+		Long:  "Show the brute force protection, suspicious IP throttling and breached password detection settings.",
+		Example: `auth0 protection show
+auth0 protection show --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var bfp *management.BruteForceProtection
+			var sit *management.SuspiciousIPThrottling
+			var bpd *management.BreachedPasswordDetection
+
+			if err := ansi.Waiting(func() error {
+				var g errgroup.Group
+				g.Go(func() (err error) {
+					bfp, err = cli.api.AttackProtection.GetBruteForceProtection()
+					return err
+				})
+				g.Go(func() (err error) {
+					sit, err = cli.api.AttackProtection.GetSuspiciousIPThrottling()
+					return err
+				})
+				g.Go(func() (err error) {
+					bpd, err = cli.api.AttackProtection.GetBreachedPasswordDetection()
+					return err
+				})
+				return g.Wait()
+			}); err != nil {
+				return fmt.Errorf("Unable to get the attack protection settings: %w", err)
+			}
+
+			cli.renderer.AttackProtectionShow(bfp, sit, bpd)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func bruteForceProtectionCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "brute-force-protection",
+		Aliases: []string{"bfp"},
+		Short:   "Manage brute force protection",
+		Long: `Manage brute force protection, which blocks an IP that keeps failing to log in
+to the same account.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showBruteForceProtectionCmd(cli))
+	cmd.AddCommand(updateBruteForceProtectionCmd(cli))
+
+	return cmd
+}
+
+func showBruteForceProtectionCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show",
+		Args:    cobra.NoArgs,
+		Short:   "Show brute force protection",
+		Long:    "Show the brute force protection settings.",
+		Example: "auth0 protection brute-force-protection show",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var bfp *management.BruteForceProtection
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				bfp, err = cli.api.AttackProtection.GetBruteForceProtection()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the brute force protection settings: %w", err)
+			}
+
+			cli.renderer.BruteForceProtectionShow(bfp)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func updateBruteForceProtectionCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Enabled     bool
+		Shields     []string
+		AllowList   []string
+		Mode        string
+		MaxAttempts int
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Args:  cobra.NoArgs,
+		Short: "Update brute force protection",
+		Long:  "Update the brute force protection settings. Settings that aren't given are left unchanged.",
+		Example: `auth0 protection brute-force-protection update --enabled
+auth0 protection bfp update --shields block,user_notification --max-attempts 5
+auth0 protection bfp update --allowlist 10.0.0.0/8,192.168.1.1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var current *management.BruteForceProtection
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				current, err = cli.api.AttackProtection.GetBruteForceProtection()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the brute force protection settings: %w", err)
+			}
+
+			if !protectionEnabled.IsSet(cmd) {
+				inputs.Enabled = current.GetEnabled()
+			}
+			if err := protectionEnabled.AskBoolU(cmd, &inputs.Enabled, current.Enabled); err != nil {
+				return err
+			}
+
+			bfp := &management.BruteForceProtection{Enabled: &inputs.Enabled}
+
+			if bfpShields.IsSet(cmd) {
+				if err := validateProtectionValues(bfpShields, inputs.Shields, bruteForceProtectionShields); err != nil {
+					return err
+				}
+				bfp.Shields = &inputs.Shields
+			}
+
+			if protectionAllowlist.IsSet(cmd) {
+				bfp.AllowList = &inputs.AllowList
+			}
+
+			if bfpMode.IsSet(cmd) {
+				if err := validateProtectionValues(bfpMode, []string{inputs.Mode}, bruteForceProtectionModes); err != nil {
+					return err
+				}
+				bfp.Mode = &inputs.Mode
+			}
+
+			if bfpMaxAttempts.IsSet(cmd) {
+				bfp.MaxAttempts = &inputs.MaxAttempts
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.AttackProtection.UpdateBruteForceProtection(bfp)
+			}); err != nil {
+				return fmt.Errorf("Unable to update the brute force protection settings: %w", err)
+			}
+
+			cli.renderer.BruteForceProtectionUpdate(bfp)
+			return nil
+		},
+	}
+
+	protectionEnabled.RegisterBoolU(cmd, &inputs.Enabled, false)
+	bfpShields.RegisterStringSliceU(cmd, &inputs.Shields, nil)
+	protectionAllowlist.RegisterStringSliceU(cmd, &inputs.AllowList, nil)
+	bfpMode.RegisterStringU(cmd, &inputs.Mode, "")
+	bfpMaxAttempts.RegisterIntU(cmd, &inputs.MaxAttempts, 0)
+
+	return cmd
+}
+
+func suspiciousIPThrottlingCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "suspicious-ip-throttling",
+		Aliases: []string{"sit"},
+		Short:   "Manage suspicious IP throttling",
+		Long: `Manage suspicious IP throttling, which throttles an IP that attempts too many
+logins or signups across accounts.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showSuspiciousIPThrottlingCmd(cli))
+	cmd.AddCommand(updateSuspiciousIPThrottlingCmd(cli))
+
+	return cmd
+}
+
+func showSuspiciousIPThrottlingCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show",
+		Args:    cobra.NoArgs,
+		Short:   "Show suspicious IP throttling",
+		Long:    "Show the suspicious IP throttling settings.",
+		Example: "auth0 protection suspicious-ip-throttling show",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sit *management.SuspiciousIPThrottling
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				sit, err = cli.api.AttackProtection.GetSuspiciousIPThrottling()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the suspicious IP throttling settings: %w", err)
+			}
+
+			cli.renderer.SuspiciousIPThrottlingShow(sit)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func updateSuspiciousIPThrottlingCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Enabled           bool
+		Shields           []string
+		AllowList         []string
+		LoginMaxAttempts  int
+		LoginRate         time.Duration
+		SignupMaxAttempts int
+		SignupRate        time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Args:  cobra.NoArgs,
+		Short: "Update suspicious IP throttling",
+		Long:  "Update the suspicious IP throttling settings. Settings that aren't given are left unchanged.",
+		Example: `auth0 protection suspicious-ip-throttling update --enabled
+auth0 protection sit update --shields block,admin_notification --allowlist 10.0.0.0/8
+auth0 protection sit update --login-max-attempts 100 --login-rate 15m
+auth0 protection sit update --signup-max-attempts 50 --signup-rate 30s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var current *management.SuspiciousIPThrottling
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				current, err = cli.api.AttackProtection.GetSuspiciousIPThrottling()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the suspicious IP throttling settings: %w", err)
+			}
+
+			if !protectionEnabled.IsSet(cmd) {
+				inputs.Enabled = current.GetEnabled()
+			}
+			if err := protectionEnabled.AskBoolU(cmd, &inputs.Enabled, current.Enabled); err != nil {
+				return err
+			}
+
+			sit := &management.SuspiciousIPThrottling{Enabled: &inputs.Enabled}
+
+			if sitShields.IsSet(cmd) {
+				if err := validateProtectionValues(sitShields, inputs.Shields, suspiciousIPThrottlingShields); err != nil {
+					return err
+				}
+				sit.Shields = &inputs.Shields
+			}
+
+			if protectionAllowlist.IsSet(cmd) {
+				sit.AllowList = &inputs.AllowList
+			}
+
+			// Each stage is replaced as a whole, so start from the current one.
+			var login *management.PreLogin
+			if sitLoginMaxAttempts.IsSet(cmd) || sitLoginRate.IsSet(cmd) {
+				login = &management.PreLogin{}
+				if stage := current.GetStage(); stage != nil && stage.PreLogin != nil {
+					*login = *stage.PreLogin
+				}
+				if sitLoginMaxAttempts.IsSet(cmd) {
+					login.MaxAttempts = &inputs.LoginMaxAttempts
+				}
+				if sitLoginRate.IsSet(cmd) {
+					login.Rate = protectionRateFor(inputs.LoginRate)
+				}
+			}
+
+			var signup *management.PreUserRegistration
+			if sitSignupMaxAttempts.IsSet(cmd) || sitSignupRate.IsSet(cmd) {
+				signup = &management.PreUserRegistration{}
+				if stage := current.GetStage(); stage != nil && stage.PreUserRegistration != nil {
+					*signup = *stage.PreUserRegistration
+				}
+				if sitSignupMaxAttempts.IsSet(cmd) {
+					signup.MaxAttempts = &inputs.SignupMaxAttempts
+				}
+				if sitSignupRate.IsSet(cmd) {
+					signup.Rate = protectionRateFor(inputs.SignupRate)
+				}
+			}
+
+			if login != nil || signup != nil {
+				sit.Stage = &management.Stage{PreLogin: login, PreUserRegistration: signup}
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.AttackProtection.UpdateSuspiciousIPThrottling(sit)
+			}); err != nil {
+				return fmt.Errorf("Unable to update the suspicious IP throttling settings: %w", err)
+			}
+
+			cli.renderer.SuspiciousIPThrottlingUpdate(sit)
+			return nil
+		},
+	}
+
+	protectionEnabled.RegisterBoolU(cmd, &inputs.Enabled, false)
+	sitShields.RegisterStringSliceU(cmd, &inputs.Shields, nil)
+	protectionAllowlist.RegisterStringSliceU(cmd, &inputs.AllowList, nil)
+	sitLoginMaxAttempts.RegisterIntU(cmd, &inputs.LoginMaxAttempts, 0)
+	sitLoginRate.RegisterDurationU(cmd, &inputs.LoginRate, 0)
+	sitSignupMaxAttempts.RegisterIntU(cmd, &inputs.SignupMaxAttempts, 0)
+	sitSignupRate.RegisterDurationU(cmd, &inputs.SignupRate, 0)
+
+	return cmd
+}
+
+func breachedPasswordDetectionCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "breached-password-detection",
+		Aliases: []string{"bpd"},
+		Short:   "Manage breached password detection",
+		Long: `Manage breached password detection, which acts when a user logs in with a
+password known to be leaked.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showBreachedPasswordDetectionCmd(cli))
+	cmd.AddCommand(updateBreachedPasswordDetectionCmd(cli))
+
+	return cmd
+}
+
+func showBreachedPasswordDetectionCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show",
+		Args:    cobra.NoArgs,
+		Short:   "Show breached password detection",
+		Long:    "Show the breached password detection settings.",
+		Example: "auth0 protection breached-password-detection show",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var bpd *management.BreachedPasswordDetection
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				bpd, err = cli.api.AttackProtection.GetBreachedPasswordDetection()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the breached password detection settings: %w", err)
+			}
+
+			cli.renderer.BreachedPasswordDetectionShow(bpd)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func updateBreachedPasswordDetectionCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Enabled               bool
+		Shields               []string
+		NotificationFrequency []string
+		Method                string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Args:  cobra.NoArgs,
+		Short: "Update breached password detection",
+		Long:  "Update the breached password detection settings. Settings that aren't given are left unchanged.",
+		Example: `auth0 protection breached-password-detection update --enabled
+auth0 protection bpd update --shields block,admin_notification --admin-notification-frequency daily
+auth0 protection bpd update --method enhanced`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var current *management.BreachedPasswordDetection
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				current, err = cli.api.AttackProtection.GetBreachedPasswordDetection()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the breached password detection settings: %w", err)
+			}
+
+			if !protectionEnabled.IsSet(cmd) {
+				inputs.Enabled = current.GetEnabled()
+			}
+			if err := protectionEnabled.AskBoolU(cmd, &inputs.Enabled, current.Enabled); err != nil {
+				return err
+			}
+
+			bpd := &management.BreachedPasswordDetection{Enabled: &inputs.Enabled}
+
+			if bpdShields.IsSet(cmd) {
+				if err := validateProtectionValues(bpdShields, inputs.Shields, breachedPasswordDetectionShields); err != nil {
+					return err
+				}
+				bpd.Shields = &inputs.Shields
+			}
+
+			if bpdAdminNotificationFrequency.IsSet(cmd) {
+				if err := validateProtectionValues(bpdAdminNotificationFrequency, inputs.NotificationFrequency, breachedPasswordFrequencies); err != nil {
+					return err
+				}
+				bpd.AdminNotificationFrequency = &inputs.NotificationFrequency
+			}
+
+			if bpdMethod.IsSet(cmd) {
+				if err := validateProtectionValues(bpdMethod, []string{inputs.Method}, breachedPasswordMethods); err != nil {
+					return err
+				}
+				bpd.Method = &inputs.Method
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.AttackProtection.UpdateBreachedPasswordDetection(bpd)
+			}); err != nil {
+				return fmt.Errorf("Unable to update the breached password detection settings: %w", err)
+			}
+
+			cli.renderer.BreachedPasswordDetectionUpdate(bpd)
+			return nil
+		},
+	}
+
+	protectionEnabled.RegisterBoolU(cmd, &inputs.Enabled, false)
+	bpdShields.RegisterStringSliceU(cmd, &inputs.Shields, nil)
+	bpdAdminNotificationFrequency.RegisterStringSliceU(cmd, &inputs.NotificationFrequency, nil)
+	bpdMethod.RegisterStringU(cmd, &inputs.Method, "")
+
+	return cmd
+}
+
+func blockedIPsCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "blocked-ips",
+		Short: "Manage the IPs blocked by suspicious IP throttling",
+		Long: `Manage the IPs blocked by suspicious IP throttling.
+
+The management API can only check one IP at a time, so blocked IPs are found in
+the 'limit_mu' logs of the tenant and then checked one by one.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(listBlockedIPsCmd(cli))
+	cmd.AddCommand(unblockBlockedIPsCmd(cli))
+
+	return cmd
+}
+
+func listBlockedIPsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Since time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Short:   "List blocked IPs",
+		Long:    "List the IPs currently blocked by suspicious IP throttling.",
+		Example: `auth0 protection blocked-ips list
+auth0 protection blocked-ips list --since 24h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				ips       []display.BlockedIP
+				truncated bool
+			)
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				ips, truncated, err = cli.blockedIPs(inputs.Since)
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to list the blocked IPs: %w", err)
+			}
+
+			if truncated {
+				warnBlockedIPsTruncated(cli)
+			}
+			cli.renderer.BlockedIPList(ips)
+			return nil
+		},
+	}
+
+	blockedIPsSince.RegisterDuration(cmd, &inputs.Since, 30*24*time.Hour)
+
+	return cmd
+}
+
+func unblockBlockedIPsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		All   bool
+		Since time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unblock",
+		Args:  cobra.ArbitraryArgs,
+		Short: "Unblock IPs",
+		Long: `Unblock IPs blocked by suspicious IP throttling. Without arguments, pick the
+IPs to unblock from the blocked ones, or unblock all of them with --all.`,
+		Example: `auth0 protection blocked-ips unblock
+auth0 protection blocked-ips unblock <ip> <ip>
+auth0 protection blocked-ips unblock --all --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ips := args
+
+			if len(ips) == 0 {
+				if !inputs.All && !canPrompt(cmd) {
+					return fmt.Errorf("Missing the IPs to unblock, pass them as arguments or use --all.")
+				}
+
+				var (
+					blocked   []display.BlockedIP
+					truncated bool
+				)
+				if err := ansi.Waiting(func() error {
+					var err error
+					blocked, truncated, err = cli.blockedIPs(inputs.Since)
+					return err
+				}); err != nil {
+					return fmt.Errorf("Unable to list the blocked IPs: %w", err)
+				}
+
+				if truncated {
+					warnBlockedIPsTruncated(cli)
+				}
+
+				if len(blocked) == 0 {
+					cli.renderer.Infof("There are no blocked IPs.")
+					return nil
+				}
+
+				for _, b := range blocked {
+					ips = append(ips, b.IP)
+				}
+
+				if !inputs.All {
+					var picked []string
+					q := &survey.Question{Prompt: &survey.MultiSelect{Message: "IPs", Options: ips}}
+					if err := prompt.AskOne(q, &picked); err != nil {
+						return err
+					}
+					ips = picked
+				}
+			}
+
+			if len(ips) == 0 {
+				return nil
+			}
+
+			if !cli.force && canPrompt(cmd) {
+				if confirmed := prompt.Confirm(fmt.Sprintf("Are you sure you want to unblock %d IPs?", len(ips))); !confirmed {
+					return nil
+				}
+			}
+
+			cli.renderer.Heading("IP")
+
+			var failed int
+			for _, ip := range ips {
+				if err := ansi.Waiting(func() error {
+					return cli.api.Anomaly.UnblockIP(ip)
+				}); err != nil {
+					cli.renderer.Errorf("Unable to unblock the IP %s: %v", ip, err)
+					failed++
+					continue
+				}
+				cli.renderer.Infof("The IP %s was unblocked", ip)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("Unable to unblock %d of %d IPs.", failed, len(ips))
+			}
+			return nil
+		},
+	}
+
+	blockedIPsAll.RegisterBool(cmd, &inputs.All, false)
+	blockedIPsSince.RegisterDuration(cmd, &inputs.Since, 30*24*time.Hour)
+
+	return cmd
+}
+
+// blockedIPs finds the IPs blocked by suspicious IP throttling in the logs
+// dated after since, and returns the ones that are still blocked. truncated
+// is true when the oldest logs couldn't be searched.
+func (c *cli) blockedIPs(since time.Duration) (ips []display.BlockedIP, truncated bool, err error) {
+	logs, truncated, err := getLogsSince(c, time.Now().Add(-since), "type:limit_mu")
+	if err != nil {
+		return nil, false, err
+	}
+
+	byIP := map[string]*display.BlockedIP{}
+	for _, l := range logs {
+		if l.GetIP() == "" {
+			continue
+		}
+
+		b, ok := byIP[l.GetIP()]
+		if !ok {
+			b = &display.BlockedIP{IP: l.GetIP(), Location: blockedIPLocation(l)}
+			byIP[l.GetIP()] = b
+		}
+		b.Blocks++
+		if l.GetDate().After(b.LastBlocked) {
+			b.LastBlocked = l.GetDate()
+		}
+	}
+
+	candidates := make([]*display.BlockedIP, 0, len(byIP))
+	for _, b := range byIP {
+		candidates = append(candidates, b)
+	}
+
+	blocked := make([]bool, len(candidates))
+	sem := make(chan struct{}, blockedIPsConcurrency)
+	var g errgroup.Group
+	for i, b := range candidates {
+		i, ip := i, b.IP
+		sem <- struct{}{}
+		g.Go(func() error {
+			defer func() { <-sem }()
+
+			var err error
+			blocked[i], err = c.api.Anomaly.CheckIP(ip)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, false, err
+	}
+
+	for i, b := range candidates {
+		if blocked[i] {
+			ips = append(ips, *b)
+		}
+	}
+
+	sort.Slice(ips, func(i, j int) bool {
+		return ips[i].LastBlocked.After(ips[j].LastBlocked)
+	})
+
+	return ips, truncated, nil
+}
+
+func warnBlockedIPsTruncated(cli *cli) {
+	cli.renderer.Warnf("Only the latest %d logs were searched for blocked IPs, use a shorter --since to search them all.", logsMaxPages*logsPerPage)
+}
+
+func blockedIPLocation(l *management.Log) string {
+	var parts []string
+	for _, k := range []string{"city_name", "country_name"} {
+		if v, _ := l.LocationInfo[k].(string); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func validateProtectionValues(f Flag, values, allowed []string) error {
+	for _, v := range values {
+		if !containsStr(allowed, v) {
+			return fmt.Errorf("Unknown %s '%s', possible values: %s", strings.ToLower(f.Name), v, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func protectionRateFor(d time.Duration) *int {
+	ms := int(d / time.Millisecond)
+	return &ms
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockedIPs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	log := func(ip string, ago time.Duration) *management.Log {
+		return &management.Log{
+			Type:         auth0.String("limit_mu"),
+			IP:           auth0.String(ip),
+			Date:         auth0.Time(now.Add(-ago)),
+			LocationInfo: map[string]interface{}{"city_name": "Lisbon", "country_name": "Portugal"},
+		}
+	}
+
+	logAPI := auth0.NewMockLogAPI(ctrl)
+	logAPI.EXPECT().List(gomock.Any()).Return([]*management.Log{
+		log("10.0.0.1", time.Hour),
+		log("10.0.0.2", 2*time.Hour),
+		log("10.0.0.1", 3*time.Hour),
+		log("10.0.0.3", time.Minute),
+	}, nil)
+
+	anomalyAPI := auth0.NewMockAnomalyAPI(ctrl)
+	anomalyAPI.EXPECT().CheckIP("10.0.0.1").Return(true, nil)
+	anomalyAPI.EXPECT().CheckIP("10.0.0.2").Return(false, nil)
+	anomalyAPI.EXPECT().CheckIP("10.0.0.3").Return(true, nil)
+
+	cli := &cli{api: &auth0.API{
+		Log:     logAPI,
+		Anomaly: anomalyAPI,
+	}}
+
+	ips, truncated, err := cli.blockedIPs(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, ips, 2)
+
+	assert.Equal(t, "10.0.0.3", ips[0].IP)
+	assert.Equal(t, 1, ips[0].Blocks)
+
+	assert.Equal(t, "10.0.0.1", ips[1].IP)
+	assert.Equal(t, 2, ips[1].Blocks)
+	assert.Equal(t, "Lisbon, Portugal", ips[1].Location)
+	assert.WithinDuration(t, now.Add(-time.Hour), ips[1].LastBlocked, time.Second)
+}
+
+func TestBlockedIPsConcurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var logs []*management.Log
+	for i := 0; i < 3*blockedIPsConcurrency; i++ {
+		logs = append(logs, &management.Log{
+			Type: auth0.String("limit_mu"),
+			IP:   auth0.String(fmt.Sprintf("10.0.0.%d", i)),
+			Date: auth0.Time(time.Now()),
+		})
+	}
+
+	logAPI := auth0.NewMockLogAPI(ctrl)
+	logAPI.EXPECT().List(gomock.Any()).Return(logs, nil)
+
+	var running, maxRunning int32
+	anomalyAPI := auth0.NewMockAnomalyAPI(ctrl)
+	anomalyAPI.EXPECT().CheckIP(gomock.Any()).Times(len(logs)).DoAndReturn(func(ip string, opts ...management.RequestOption) (bool, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return true, nil
+	})
+
+	cli := &cli{api: &auth0.API{Log: logAPI, Anomaly: anomalyAPI}}
+
+	ips, _, err := cli.blockedIPs(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, ips, len(logs))
+	assert.LessOrEqual(t, int(maxRunning), blockedIPsConcurrency)
+}
+
+func TestValidateProtectionValues(t *testing.T) {
+	assert.NoError(t, validateProtectionValues(bfpShields, []string{"block", "user_notification"}, bruteForceProtectionShields))
+	assert.NoError(t, validateProtectionValues(bfpShields, nil, bruteForceProtectionShields))
+	assert.EqualError(t,
+		validateProtectionValues(bfpShields, []string{"block", "admin_notification"}, bruteForceProtectionShields),
+		"Unknown shields 'admin_notification', possible values: block, user_notification")
+}
+
+func TestProtectionRateFor(t *testing.T) {
+	assert.Equal(t, 900000, *protectionRateFor(15 * time.Minute))
+}
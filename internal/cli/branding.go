@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/auth0/auth0-cli/internal/ansi"
 	"github.com/auth0/auth0-cli/internal/branding"
@@ -63,6 +64,27 @@ var (
 		AlwaysPrompt: true,
 	}
 
+	templateFile = Flag{
+		Name:      "Template File",
+		LongForm:  "file",
+		ShortForm: "f",
+		Help:      "Path to a local page template. When set, the tenant isn't contacted.",
+	}
+
+	templateOut = Flag{
+		Name:      "Output File",
+		LongForm:  "out",
+		ShortForm: "o",
+		Help:      "Path of the HTML file to write the rendered page to.",
+	}
+
+	templatePrompt = Flag{
+		Name:      "Prompt",
+		LongForm:  "prompt",
+		ShortForm: "p",
+		Help:      "Universal Login prompt to render the template for, e.g. login or signup.",
+	}
+
 	customTemplateOptions = pickerOptions{
 		{"Basic", branding.DefaultTemplate},
 		{"Login box + image", branding.ImageTemplate},
@@ -114,6 +136,8 @@ Once you close the window, you’ll be asked if you want to save the template. I
 	cmd.SetUsageTemplate(resourceUsageTemplate())
 	cmd.AddCommand(showBrandingTemplateCmd(cli))
 	cmd.AddCommand(updateBrandingTemplateCmd(cli))
+	cmd.AddCommand(lintBrandingTemplateCmd(cli))
+	cmd.AddCommand(renderBrandingTemplateCmd(cli))
 	return cmd
 }
 
@@ -127,6 +151,8 @@ func emailTemplateCmd(cli *cli) *cobra.Command {
 	cmd.SetUsageTemplate(resourceUsageTemplate())
 	cmd.AddCommand(showEmailTemplateCmd(cli))
 	cmd.AddCommand(updateEmailTemplateCmd(cli))
+	cmd.AddCommand(previewEmailTemplateCmd(cli))
+	cmd.AddCommand(emailProviderCmd(cli))
 	return cmd
 }
 
@@ -353,6 +379,139 @@ func updateBrandingTemplateCmd(cli *cli) *cobra.Command {
 	return cmd
 }
 
+func lintBrandingTemplateCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		File string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Args:  cobra.NoArgs,
+		Short: "Check the custom template for Universal Login",
+		Long: `Check the custom template for Universal Login.
+
+The template is parsed offline and checked for the required tags and size
+limits. The command fails when the template would be rejected by Auth0, which
+makes it suitable to gate template changes in CI.`,
+		Example: `auth0 branding templates lint
+auth0 branding templates lint --file template.liquid`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			body, err := cli.loadCustomTemplateBody(inputs.File)
+			if err != nil {
+				return err
+			}
+
+			issues := branding.LintTemplate(body)
+			cli.renderer.TemplateLint(issues)
+
+			if branding.HasLintErrors(issues) {
+				return errors.New("The template has errors that would prevent it from being saved.")
+			}
+
+			return nil
+		},
+	}
+
+	templateFile.RegisterString(cmd, &inputs.File, "")
+	return cmd
+}
+
+func renderBrandingTemplateCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		File   string
+		Out    string
+		Prompt string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Args:  cobra.NoArgs,
+		Short: "Render the custom template for Universal Login to a static page",
+		Long: `Render the custom template for Universal Login to a static HTML page.
+
+The Auth0 tags are replaced with a static approximation of the login widget
+using the tenant branding, or the default branding when rendering a local file.`,
+		Example: `auth0 branding templates render --out page.html
+auth0 branding templates render --file template.liquid --out page.html --prompt signup`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var templateData *branding.TemplateData
+
+			if inputs.File != "" {
+				body, err := cli.loadCustomTemplateBody(inputs.File)
+				if err != nil {
+					return err
+				}
+
+				templateData = &branding.TemplateData{
+					PrimaryColor:    defaultPrimaryColor,
+					BackgroundColor: defaultBackgroundColor,
+					LogoURL:         defaultLogoURL,
+					TenantName:      "My Tenant",
+					Body:            body,
+				}
+			} else {
+				err := ansi.Waiting(func() error {
+					var err error
+					templateData, err = cli.obtainCustomTemplateData(cmd.Context())
+					return err
+				})
+				if err != nil {
+					return err
+				}
+
+				if templateData.Body == "" {
+					return errors.New("The tenant doesn't have a custom template, use 'auth0 branding templates update' to create one.")
+				}
+			}
+
+			page, err := branding.RenderTemplate(*templateData, inputs.Prompt)
+			if err != nil {
+				return fmt.Errorf("Unable to render the template: %w", err)
+			}
+
+			if inputs.Out == "" {
+				cli.renderer.Output(page)
+				return nil
+			}
+
+			if err := ioutil.WriteFile(inputs.Out, []byte(page), 0644); err != nil {
+				return fmt.Errorf("Unable to write the rendered template: %w", err)
+			}
+
+			cli.renderer.Infof("Template rendered to %s", inputs.Out)
+			return nil
+		},
+	}
+
+	templateFile.RegisterString(cmd, &inputs.File, "")
+	templateOut.RegisterString(cmd, &inputs.Out, "")
+	templatePrompt.RegisterString(cmd, &inputs.Prompt, branding.DefaultPrompt)
+	return cmd
+}
+
+// loadCustomTemplateBody reads a page template from the given file, or from
+// the tenant when no file is given.
+func (cli *cli) loadCustomTemplateBody(file string) (string, error) {
+	if file != "" {
+		buf, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("Unable to read the template file: %w", err)
+		}
+		return string(buf), nil
+	}
+
+	var template *management.BrandingUniversalLogin
+	if err := ansi.Waiting(func() error {
+		var err error
+		template, err = cli.api.Branding.UniversalLogin()
+		return err
+	}); err != nil {
+		return "", fmt.Errorf("Unable to load the Universal Login template due to an unexpected error: %w", err)
+	}
+
+	return template.GetBody(), nil
+}
+
 func (cli *cli) customTemplateEditorPromptWithPreview(cmd *cobra.Command, body *string, templateData branding.TemplateData) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/display"
 	"github.com/auth0/auth0-cli/internal/iostream"
+	"github.com/auth0/go-auth0/management"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +33,31 @@ var (
 		Help:       "Language of the custom text.",
 		IsRequired: true,
 	}
+
+	textLanguages = Flag{
+		Name:      "Languages",
+		LongForm:  "language",
+		ShortForm: "l",
+		Help:      "Languages to export. Defaults to all the languages enabled for the tenant.",
+	}
+
+	textDir = Flag{
+		Name:       "Directory",
+		LongForm:   "dir",
+		ShortForm:  "d",
+		Help:       "Directory holding one folder per language, with a JSON file per prompt.",
+		IsRequired: true,
+	}
+
+	// brandingTextPrompts are the prompts that support custom texts.
+	brandingTextPrompts = []string{
+		"common", "consent", "device-flow", "email-otp-challenge",
+		"email-verification", "invitation", "login", "login-email-verification",
+		"login-id", "login-password", "mfa", "mfa-email", "mfa-otp", "mfa-phone",
+		"mfa-push", "mfa-recovery-code", "mfa-sms", "mfa-voice", "mfa-webauthn",
+		"organizations", "reset-password", "signup", "signup-id",
+		"signup-password", "status",
+	}
 )
 
 func textsCmd(cli *cli) *cobra.Command {
@@ -40,6 +70,8 @@ func textsCmd(cli *cli) *cobra.Command {
 	cmd.SetUsageTemplate(resourceUsageTemplate())
 	cmd.AddCommand(showBrandingTextCmd(cli))
 	cmd.AddCommand(updateBrandingTextCmd(cli))
+	cmd.AddCommand(exportBrandingTextsCmd(cli))
+	cmd.AddCommand(importBrandingTextsCmd(cli))
 
 	return cmd
 }
@@ -104,7 +136,7 @@ auth0 branding texts update <prompt> -l es`,
 			currentBody[textDocsKey] = brandingTextDocsURL(prompt)
 
 			if err := ansi.Waiting(func() error {
-				defaultTranslations := downloadBrandingTextLocale(fileName)
+				defaultTranslations := downloadBrandingTextLocale(cli.defaultClient(), fileName)
 
 				customTranslations, err := cli.api.Prompt.CustomText(prompt, inputs.Language)
 				if err != nil {
@@ -161,6 +193,308 @@ auth0 branding texts update <prompt> -l es`,
 	return cmd
 }
 
+func exportBrandingTextsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Dir       string
+		Languages []string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Args:  cobra.NoArgs,
+		Short: "Export the custom texts of every prompt and language",
+		Long: `Export the custom texts of every prompt and language to a directory.
+
+A folder is created per language, holding a JSON file per prompt. The files
+of the tenant default language (the first enabled one) are merged with the
+Auth0 default texts so they list every key, while the other languages only
+hold their custom texts. A completeness report then shows the keys missing
+from each language compared to the default one.`,
+		Example: `auth0 branding texts export --dir texts/
+auth0 branding texts export --dir texts/ --language es,fr`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defaultLanguage, enabled, err := cli.brandingTextLanguages()
+			if err != nil {
+				return err
+			}
+
+			languages := inputs.Languages
+			if len(languages) == 0 {
+				languages = enabled
+			}
+			if !containsStr(languages, defaultLanguage) {
+				languages = append([]string{defaultLanguage}, languages...)
+			}
+
+			texts := map[string]map[string]map[string]interface{}{}
+			err = ansi.Spinner("Exporting custom texts", func() error {
+				for _, language := range languages {
+					texts[language] = map[string]map[string]interface{}{}
+
+					for _, prompt := range brandingTextPrompts {
+						custom, err := cli.api.Prompt.CustomText(prompt, language)
+						if err != nil {
+							return fmt.Errorf("Unable to load custom text for prompt %s and language %s: %w", prompt, language, err)
+						}
+
+						if language == defaultLanguage {
+							defaults := downloadBrandingTextLocale(cli.defaultClient(), fmt.Sprintf("%s.%s.json", prompt, language))
+							custom = brandingTextsAsBody(mergeBrandingTextLocales(defaults, custom))
+						}
+
+						texts[language][prompt] = custom
+						if err := writeBrandingTexts(inputs.Dir, language, prompt, custom); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			cli.renderer.Infof("Custom texts exported to %s", inputs.Dir)
+			cli.renderer.BrandingTextsCompleteness(brandingTextsCompleteness(texts, defaultLanguage))
+			return nil
+		},
+	}
+
+	textDir.RegisterString(cmd, &inputs.Dir, "")
+	textLanguages.RegisterStringSlice(cmd, &inputs.Languages, nil)
+	return cmd
+}
+
+func importBrandingTextsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Dir string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Args:  cobra.NoArgs,
+		Short: "Import the custom texts of every prompt and language",
+		Long: `Import the custom texts of every prompt and language from a directory.
+
+The directory is expected to follow the layout written by 'export': a folder
+per language, holding a JSON file per prompt. Existing custom texts for an
+imported prompt and language are overwritten, empty files are skipped.`,
+		Example: `auth0 branding texts import --dir texts/`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			texts, err := readBrandingTexts(inputs.Dir)
+			if err != nil {
+				return err
+			}
+
+			if len(texts) == 0 {
+				return fmt.Errorf("No custom texts found in %s", inputs.Dir)
+			}
+
+			defaultLanguage, enabled, err := cli.brandingTextLanguages()
+			if err != nil {
+				return err
+			}
+
+			for language := range texts {
+				if !containsStr(enabled, language) {
+					cli.renderer.Warnf("The %s language isn't enabled for the tenant, its custom texts won't be shown until it is.", language)
+				}
+			}
+
+			imported := 0
+			err = ansi.Spinner("Importing custom texts", func() error {
+				for _, language := range brandingTextLanguageNames(texts) {
+					for _, prompt := range brandingTextPromptNames(texts[language]) {
+						body := texts[language][prompt]
+						if len(body) == 0 {
+							continue
+						}
+
+						if err := cli.api.Prompt.SetCustomText(prompt, language, body); err != nil {
+							return fmt.Errorf("Unable to set custom text for prompt %s and language %s: %w", prompt, language, err)
+						}
+						imported++
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			cli.renderer.Infof("Imported the custom texts of %d prompts", imported)
+			if _, ok := texts[defaultLanguage]; ok {
+				cli.renderer.BrandingTextsCompleteness(brandingTextsCompleteness(texts, defaultLanguage))
+			}
+			return nil
+		},
+	}
+
+	textDir.RegisterString(cmd, &inputs.Dir, "")
+	return cmd
+}
+
+// brandingTextLanguages returns the tenant default language along with all
+// the enabled ones.
+func (c *cli) brandingTextLanguages() (string, []string, error) {
+	var tenant *management.Tenant
+	if err := ansi.Waiting(func() error {
+		var err error
+		tenant, err = c.api.Tenant.Read()
+		return err
+	}); err != nil {
+		return "", nil, fmt.Errorf("Unable to load tenant settings: %w", err)
+	}
+
+	var languages []string
+	for _, l := range tenant.EnabledLocales {
+		if language, ok := l.(string); ok {
+			languages = append(languages, language)
+		}
+	}
+
+	if len(languages) == 0 {
+		return textLanguageDefault, []string{textLanguageDefault}, nil
+	}
+
+	return languages[0], languages, nil
+}
+
+func writeBrandingTexts(dir, language, prompt string, body map[string]interface{}) error {
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+
+	bodyStr, err := marshalBrandingTextBody(body)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, language), 0755); err != nil {
+		return fmt.Errorf("Unable to create the %s directory: %w", language, err)
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, language, prompt+".json"), []byte(bodyStr+"\n"), 0644)
+}
+
+// readBrandingTexts reads the custom texts from a directory, keyed by
+// language and prompt.
+func readBrandingTexts(dir string) (map[string]map[string]map[string]interface{}, error) {
+	languages, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read %s: %w", dir, err)
+	}
+
+	texts := map[string]map[string]map[string]interface{}{}
+	for _, language := range languages {
+		if !language.IsDir() {
+			continue
+		}
+
+		files, err := filepath.Glob(filepath.Join(dir, language.Name(), "*.json"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			prompt := strings.TrimSuffix(filepath.Base(file), ".json")
+			if !containsStr(brandingTextPrompts, prompt) {
+				return nil, fmt.Errorf("Unknown prompt %s in %s", prompt, file)
+			}
+
+			buf, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(buf, &body); err != nil {
+				return nil, fmt.Errorf("Unable to parse %s: %w", file, err)
+			}
+			delete(body, textDocsKey)
+
+			if _, ok := texts[language.Name()]; !ok {
+				texts[language.Name()] = map[string]map[string]interface{}{}
+			}
+			texts[language.Name()][prompt] = body
+		}
+	}
+
+	return texts, nil
+}
+
+// brandingTextsCompleteness compares the keys of every language to the ones
+// of the default language.
+func brandingTextsCompleteness(texts map[string]map[string]map[string]interface{}, defaultLanguage string) []display.BrandingTextsCompleteness {
+	want := brandingTextKeys(texts[defaultLanguage])
+
+	var report []display.BrandingTextsCompleteness
+	for _, language := range brandingTextLanguageNames(texts) {
+		got := brandingTextKeys(texts[language])
+
+		item := display.BrandingTextsCompleteness{
+			Language: language,
+			Keys:     len(got),
+			Total:    len(want),
+			Missing:  []string{},
+		}
+		for key := range want {
+			if !got[key] {
+				item.Missing = append(item.Missing, key)
+			}
+		}
+		sort.Strings(item.Missing)
+
+		report = append(report, item)
+	}
+
+	return report
+}
+
+// brandingTextKeys flattens the custom texts of a language to a set of
+// `prompt/screen.key` keys.
+func brandingTextKeys(prompts map[string]map[string]interface{}) map[string]bool {
+	keys := map[string]bool{}
+	for prompt, screens := range prompts {
+		for screen, v := range screens {
+			translations, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for key := range translations {
+				keys[fmt.Sprintf("%s/%s.%s", prompt, screen, key)] = true
+			}
+		}
+	}
+	return keys
+}
+
+func brandingTextsAsBody(texts map[string]map[string]interface{}) map[string]interface{} {
+	body := map[string]interface{}{}
+	for k, v := range texts {
+		body[k] = v
+	}
+	return body
+}
+
+func brandingTextLanguageNames(texts map[string]map[string]map[string]interface{}) []string {
+	languages := make([]string, 0, len(texts))
+	for language := range texts {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+func brandingTextPromptNames(prompts map[string]map[string]interface{}) []string {
+	names := make([]string, 0, len(prompts))
+	for prompt := range prompts {
+		names = append(names, prompt)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (c *cli) promptTextEditorHint() {
 	c.renderer.Infof("%s once you close the editor, the custom text will be saved. To cancel, CTRL+C.", ansi.Faint("Hint:"))
 }
@@ -182,9 +516,9 @@ func marshalBrandingTextBody(b map[string]interface{}) (string, error) {
 	return buf.String(), nil
 }
 
-func downloadBrandingTextLocale(filename string) map[string]interface{} {
+func downloadBrandingTextLocale(client *http.Client, filename string) map[string]interface{} {
 	url := fmt.Sprintf("%s/%s", textLocalesURL, filename)
-	resp, err := http.Get(url)
+	resp, err := client.Get(url)
 	if err != nil {
 		return nil
 	}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrandingTextsExportImport(t *testing.T) {
+	dir := t.TempDir()
+
+	english := map[string]interface{}{
+		"login": map[string]interface{}{
+			"title":       "Welcome",
+			"description": "Log in to continue",
+		},
+	}
+	spanish := map[string]interface{}{
+		"login": map[string]interface{}{
+			"title": "Bienvenido",
+		},
+	}
+
+	assert.NoError(t, writeBrandingTexts(dir, "en", "login", english))
+	assert.NoError(t, writeBrandingTexts(dir, "es", "login", spanish))
+	assert.NoError(t, writeBrandingTexts(dir, "es", "signup", nil))
+
+	texts, err := readBrandingTexts(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"en", "es"}, brandingTextLanguageNames(texts))
+	assert.Equal(t, []string{"login", "signup"}, brandingTextPromptNames(texts["es"]))
+	assert.Empty(t, texts["es"]["signup"])
+
+	report := brandingTextsCompleteness(texts, "en")
+	assert.Len(t, report, 2)
+	assert.Equal(t, "en", report[0].Language)
+	assert.Empty(t, report[0].Missing)
+	assert.Equal(t, "es", report[1].Language)
+	assert.Equal(t, 1, report[1].Keys)
+	assert.Equal(t, 2, report[1].Total)
+	assert.Equal(t, []string{"login/login.description"}, report[1].Missing)
+}
+
+func TestReadBrandingTextsUnknownPrompt(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, writeBrandingTexts(dir, "en", "not-a-prompt", nil))
+
+	_, err := readBrandingTexts(dir)
+	assert.Error(t, err)
+}
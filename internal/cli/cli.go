@@ -10,7 +10,6 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -21,39 +20,95 @@ import (
 	"github.com/auth0/auth0-cli/internal/buildinfo"
 	"github.com/auth0/auth0-cli/internal/display"
 	"github.com/auth0/auth0-cli/internal/iostream"
+	"github.com/auth0/auth0-cli/internal/transport"
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/jwt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/oauth2"
 	"github.com/auth0/go-auth0/management"
 )
 
 const (
 	userAgent               = "Auth0 CLI"
 	accessTokenExpThreshold = 5 * time.Minute
+
+	// replayTenant and replayToken stand for the tenant and its access
+	// token when replaying fixtures, which don't check them.
+	replayTenant = "replay.auth0.com"
+	replayToken  = "replay"
 )
 
 // config defines the exact set of tenants, access tokens, which only exists
 // for a particular user's machine.
 type config struct {
-	InstallID     string            `json:"install_id,omitempty"`
-	DefaultTenant string            `json:"default_tenant"`
-	Tenants       map[string]tenant `json:"tenants"`
+	InstallID      string             `json:"install_id,omitempty"`
+	DefaultTenant  string             `json:"default_tenant"`
+	SecretsBackend string             `json:"secrets_backend,omitempty"`
+	DefaultProfile string             `json:"default_profile,omitempty"`
+	Tenants        map[string]tenant  `json:"tenants"`
+	Profiles       map[string]profile `json:"profiles,omitempty"`
+	CAFile         string             `json:"ca_file,omitempty"`
+	ClientCert     string             `json:"client_cert,omitempty"`
+	ClientKey      string             `json:"client_key,omitempty"`
 }
 
 // tenant is the cli's concept of an auth0 tenant. The fields are tailor fit
-// specifically for interacting with the management API.
+// specifically for interacting with the management API. The access token and
+// client secret are kept in the secret store, and only referenced from the
+// config file.
 type tenant struct {
-	Name         string         `json:"name"`
-	Domain       string         `json:"domain"`
-	AccessToken  string         `json:"access_token,omitempty"`
-	Scopes       []string       `json:"scopes,omitempty"`
-	ExpiresAt    time.Time      `json:"expires_at"`
-	Apps         map[string]app `json:"apps,omitempty"`
-	DefaultAppID string         `json:"default_app_id,omitempty"`
-
-	ClientID     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
+	Name           string         `json:"name"`
+	Domain         string         `json:"domain"`
+	AccessToken    string         `json:"access_token,omitempty"`
+	AccessTokenRef string         `json:"access_token_ref,omitempty"`
+	Scopes         []string       `json:"scopes,omitempty"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+	Apps           map[string]app `json:"apps,omitempty"`
+	DefaultAppID   string         `json:"default_app_id,omitempty"`
+
+	ClientID        string `json:"client_id"`
+	ClientSecret    string `json:"client_secret,omitempty"`
+	ClientSecretRef string `json:"client_secret_ref,omitempty"`
+
+	// Private Key JWT client authentication, see config init. The key itself
+	// isn't stored: it's read from its file or env var on each renewal.
+	ClientAssertionKeyPath string `json:"client_assertion_key_path,omitempty"`
+	ClientAssertionKeyEnv  string `json:"client_assertion_key_env,omitempty"`
+	ClientAssertionKeyID   string `json:"client_assertion_key_id,omitempty"`
+	ClientAssertionAlg     string `json:"client_assertion_alg,omitempty"`
+}
+
+// usesPrivateKeyJWT tells whether the tenant authenticates with Private Key
+// JWT.
+func (t *tenant) usesPrivateKeyJWT() bool {
+	return t.ClientID != "" && (t.ClientAssertionKeyPath != "" || t.ClientAssertionKeyEnv != "")
+}
+
+// privateKeyJWT loads the key the tenant authenticates with.
+func (t *tenant) privateKeyJWT() (*auth.PrivateKeyJWT, error) {
+	p := params{
+		clientDomain:     t.Domain,
+		clientID:         t.ClientID,
+		assertionKeyPath: t.ClientAssertionKeyPath,
+		assertionKeyID:   t.ClientAssertionKeyID,
+		assertionAlg:     t.ClientAssertionAlg,
+	}
+	if t.ClientAssertionKeyEnv != "" {
+		p.assertionKey = os.Getenv(t.ClientAssertionKeyEnv)
+	}
+
+	pk, err := p.privateKeyJWT()
+	if err != nil {
+		return nil, err
+	}
+	if pk == nil && t.ClientAssertionKeyEnv != "" {
+		return nil, fmt.Errorf("the client assertion key of %s is read from %s, which isn't set", t.Domain, t.ClientAssertionKeyEnv)
+	}
+	if pk == nil {
+		return nil, fmt.Errorf("the client assertion key of %s at %s is empty", t.Domain, t.ClientAssertionKeyPath)
+	}
+	return pk, nil
 }
 
 type app struct {
@@ -73,6 +128,7 @@ var errUnauthenticated = errors.New("Not logged in. Try 'auth0 login'.")
 // 1. --format
 // 2. --tenant
 // 3. --debug
+// 4. --trace-file
 //
 type cli struct {
 	// core primitives exposed to command builders.
@@ -87,12 +143,33 @@ type cli struct {
 	force   bool
 	noInput bool
 	noColor bool
+	profile string
+
+	// traceFile is where the requests are traced to, as a HAR archive.
+	traceFile string
+	har       *transport.HAR
+
+	// TLS settings of the connections, and the transport they configure.
+	caFile        string
+	clientCert    string
+	clientKey     string
+	baseTransport *http.Transport
+
+	// recordDir and replayDir are where the requests are recorded to, or
+	// replayed from, as fixtures.
+	recordDir string
+	replayDir string
+	fixtures  http.RoundTripper
 
 	// config state management.
 	initOnce sync.Once
 	errOnce  error
 	path     string
 	config   config
+
+	// secret store management.
+	secrets       auth.SecretStore
+	storedSecrets map[string]string
 }
 
 // isLoggedIn encodes the domain logic for determining whether or not we're
@@ -105,8 +182,15 @@ func (c *cli) isLoggedIn() bool {
 		return false
 	}
 
+	// The access token isn't read from the secret store here, to not prompt
+	// for the passphrase of the secrets file when not otherwise needed.
+	ten := c.config.Tenants[c.tenant]
+	if ten.AccessToken == "" {
+		return ten.AccessTokenRef != "" && time.Now().Before(ten.ExpiresAt)
+	}
+
 	// Parse the access token for the tenant.
-	t, err := jwt.ParseString(c.config.Tenants[c.tenant].AccessToken)
+	t, err := jwt.ParseString(ten.AccessToken)
 	if err != nil {
 		return false
 	}
@@ -125,30 +209,44 @@ func (c *cli) isLoggedIn() bool {
 //
 // 1. A tenant is found.
 // 2. The tenant has an access token.
-func (c *cli) setup(ctx context.Context) error {
+func (c *cli) setup(ctx context.Context, scopes []string) error {
+	ua := fmt.Sprintf("%v/%v", userAgent, strings.TrimPrefix(buildinfo.Version, "v"))
+
+	if c.replayDir != "" {
+		return c.setupReplay(ua)
+	}
+
 	if err := c.init(); err != nil {
 		return err
 	}
 
-	t, err := c.prepareTenant(ctx)
+	if err := c.migrateTenantSecrets(); err != nil {
+		return err
+	}
+
+	t, err := c.prepareTenant(ctx, scopes)
 	if err != nil {
 		return err
 	}
 
-	var (
-		m  *management.Management
-		ua = fmt.Sprintf("%v/%v", userAgent, strings.TrimPrefix(buildinfo.Version, "v"))
-	)
+	var m *management.Management
 
+	// Tenants using Private Key JWT have had their access token renewed by
+	// prepareTenant, so they're set up with it like interactive logins.
 	if t.ClientID != "" && t.ClientSecret != "" {
 		m, err = management.New(t.Domain,
+			// The token is fetched with the context's client, which
+			// must be set first.
+			management.WithContext(context.WithValue(ctx, oauth2.HTTPClient, c.defaultClient())),
 			management.WithClientCredentials(t.ClientID, t.ClientSecret),
 			management.WithUserAgent(ua),
+			management.WithClient(c.httpClient()),
 		)
 	} else {
 		m, err = management.New(t.Domain,
 			management.WithStaticToken(t.AccessToken),
 			management.WithUserAgent(ua),
+			management.WithClient(c.httpClient()),
 		)
 	}
 
@@ -160,11 +258,37 @@ func (c *cli) setup(ctx context.Context) error {
 	return nil
 }
 
+// setupReplay sets up the Management API to be answered by the --replay
+// fixtures, which respond whichever the tenant and the token. No tenant is
+// prepared, so that nothing is logged in, renewed or saved, and no config is
+// needed, e.g. in CI.
+func (c *cli) setupReplay(ua string) error {
+	if err := c.init(); err != nil && !errors.Is(err, errUnauthenticated) {
+		return err
+	}
+	if c.tenant == "" {
+		c.tenant = replayTenant
+		c.renderer.Tenant = c.tenant
+	}
+
+	m, err := management.New(c.tenant,
+		management.WithStaticToken(replayToken),
+		management.WithUserAgent(ua),
+		management.WithClient(c.httpClient()),
+	)
+	if err != nil {
+		return err
+	}
+
+	c.api = auth0.NewAPI(m)
+	return nil
+}
+
 // prepareTenant loads the tenant, refreshing its token if necessary.
 // The tenant access token needs a refresh if:
-// 1. the tenant scopes are different than the currently required scopes.
+// 1. some of the given scopes weren't granted on login.
 // 2. the access token is expired.
-func (c *cli) prepareTenant(ctx context.Context) (tenant, error) {
+func (c *cli) prepareTenant(ctx context.Context, scopes []string) (tenant, error) {
 	t, err := c.getTenant()
 	if err != nil {
 		return tenant{}, err
@@ -174,8 +298,20 @@ func (c *cli) prepareTenant(ctx context.Context) (tenant, error) {
 		return t, nil
 	}
 
-	if t.AccessToken == "" || scopesChanged(t) {
-		t, err = RunLogin(ctx, c, true)
+	if t.usesPrivateKeyJWT() {
+		if t.AccessToken == "" || isExpired(t.ExpiresAt, accessTokenExpThreshold) {
+			return c.renewPrivateKeyJWTToken(ctx, t)
+		}
+		return t, nil
+	}
+
+	if t.AccessToken == "" {
+		t, err = RunLogin(ctx, c, true, t.Scopes)
+		if err != nil {
+			return tenant{}, err
+		}
+	} else if missing := missingScopes(t.Scopes, scopes); len(missing) > 0 {
+		t, err = c.consentScopes(ctx, t, missing)
 		if err != nil {
 			return tenant{}, err
 		}
@@ -184,8 +320,8 @@ func (c *cli) prepareTenant(ctx context.Context) (tenant, error) {
 		// use the refresh token to get a new access token:
 		tr := &auth.TokenRetriever{
 			Authenticator: c.authenticator,
-			Secrets:       &auth.Keyring{},
-			Client:        http.DefaultClient,
+			Secrets:       c.secretStore(),
+			Client:        c.defaultClient(),
 		}
 
 		// NOTE(cyx): this code will have to be adapted to instead
@@ -195,7 +331,7 @@ func (c *cli) prepareTenant(ctx context.Context) (tenant, error) {
 		if err != nil {
 			// ask and guide the user through the login process:
 			c.renderer.Errorf("failed to renew access token, %s", err)
-			t, err = RunLogin(ctx, c, true)
+			t, err = RunLogin(ctx, c, true, t.Scopes)
 			if err != nil {
 				return tenant{}, err
 			}
@@ -216,35 +352,38 @@ func (c *cli) prepareTenant(ctx context.Context) (tenant, error) {
 	return t, nil
 }
 
-// isExpired is true if now() + a threshold is after the given date
-func isExpired(t time.Time, threshold time.Duration) bool {
-	return time.Now().Add(threshold).After(t)
-}
-
-// scopesChanged compare the tenant scopes
-// with the currently required scopes.
-func scopesChanged(t tenant) bool {
-	want := auth.RequiredScopes()
-	got := t.Scopes
-
-	sort.Strings(want)
-	sort.Strings(got)
+// renewPrivateKeyJWTToken gets a new access token for a tenant which
+// authenticates with Private Key JWT, and persists it.
+func (c *cli) renewPrivateKeyJWTToken(ctx context.Context, t tenant) (tenant, error) {
+	pk, err := t.privateKeyJWT()
+	if err != nil {
+		return tenant{}, err
+	}
 
-	if (want == nil) != (got == nil) {
-		return true
+	scopes := auth.RequiredScopesMin()
+	res, err := pk.Token(ctx, c.defaultClient(), "https://"+t.Domain+"/api/v2/", scopes)
+	if err != nil {
+		return tenant{}, err
 	}
 
-	if len(want) != len(got) {
-		return true
+	t.AccessToken = res.AccessToken
+	t.ExpiresAt = time.Now().Add(time.Duration(res.ExpiresIn) * time.Second)
+	// The scope is only left out of the response when it's the requested one.
+	t.Scopes = scopes
+	if res.Scope != "" {
+		t.Scopes = strings.Fields(res.Scope)
 	}
 
-	for i := range t.Scopes {
-		if want[i] != got[i] {
-			return true
-		}
+	if err := c.addTenant(t); err != nil {
+		return tenant{}, fmt.Errorf("unexpected error adding tenant to config: %w", err)
 	}
 
-	return false
+	return t, nil
+}
+
+// isExpired is true if now() + a threshold is after the given date
+func isExpired(t time.Time, threshold time.Duration) bool {
+	return time.Now().Add(threshold).After(t)
 }
 
 // getTenant fetches the default tenant configured (or the tenant specified via
@@ -263,6 +402,11 @@ func (c *cli) getTenant() (tenant, error) {
 		t.Apps = map[string]app{}
 	}
 
+	if err := c.loadTenantSecrets(&t); err != nil {
+		return tenant{}, err
+	}
+	c.config.Tenants[c.tenant] = t
+
 	return t, nil
 }
 
@@ -341,7 +485,11 @@ func (c *cli) removeTenant(ten string) error {
 		return fmt.Errorf("Unexpected error persisting config: %w", err)
 	}
 
-	tr := &auth.TokenRetriever{Secrets: &auth.Keyring{}}
+	if err := c.deleteTenantSecrets(ten); err != nil {
+		return fmt.Errorf("Unexpected error clearing tenant information: %w", err)
+	}
+
+	tr := &auth.TokenRetriever{Secrets: c.secretStore()}
 	if err := tr.Delete(ten); err != nil {
 		return fmt.Errorf("Unexpected error clearing tenant information: %w", err)
 	}
@@ -428,7 +576,19 @@ func (c *cli) persistConfig() error {
 		}
 	}
 
-	buf, err := json.MarshalIndent(c.config, "", "    ")
+	// Credentials are written to the secret store instead, and never to
+	// the config file.
+	cfg := c.config
+	cfg.Tenants = make(map[string]tenant, len(c.config.Tenants))
+	for domain, t := range c.config.Tenants {
+		stored := t
+		if err := c.storeTenantSecrets(&stored); err != nil {
+			return fmt.Errorf("Unable to store the credentials of %s securely: %w", domain, err)
+		}
+		cfg.Tenants[domain] = stored
+	}
+
+	buf, err := json.MarshalIndent(cfg, "", "    ")
 	if err != nil {
 		return err
 	}
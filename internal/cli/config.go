@@ -3,15 +3,27 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/auth0/auth0-cli/internal/auth"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+const (
+	defaultClientAssertionAlg = "RS256"
+
+	// clientAssertionKeyEnv holds the client assertion key. It's read again
+	// each time the access token is renewed, as the key isn't stored.
+	clientAssertionKeyEnv = "AUTH0_CLI_CLIENT_ASSERTION_KEY"
+)
+
 var desiredInputs = `Config init is intended for non-interactive use, 
 ensure the following env variables are set: 
 
@@ -19,6 +31,13 @@ AUTH0_CLI_CLIENT_DOMAIN
 AUTH0_CLI_CLIENT_ID
 AUTH0_CLI_CLIENT_SECRET
 
+Or, to authenticate with Private Key JWT instead of a client secret:
+
+AUTH0_CLI_CLIENT_ASSERTION_KEY (the PEM encoded private key, read again
+  on each renewal) or AUTH0_CLI_CLIENT_ASSERTION_KEY_PATH
+AUTH0_CLI_CLIENT_ASSERTION_KEY_ID (optional)
+AUTH0_CLI_CLIENT_ASSERTION_ALG (optional, defaults to RS256)
+
 Interactive logins should use "auth0 login" instead.`
 
 type params struct {
@@ -26,6 +45,12 @@ type params struct {
 	clientDomain string
 	clientID     string
 	clientSecret string
+
+	// Private Key JWT client authentication.
+	assertionKey     string
+	assertionKeyPath string
+	assertionKeyID   string
+	assertionAlg     string
 }
 
 func (p params) validate() error {
@@ -46,12 +71,56 @@ func (p params) validate() error {
 		return fmt.Errorf("missing client id:\n%s", desiredInputs)
 	}
 
-	if p.clientSecret == "" {
+	usesPrivateKeyJWT := p.assertionKey != "" || p.assertionKeyPath != ""
+
+	if p.clientSecret == "" && !usesPrivateKeyJWT {
 		return fmt.Errorf("missing client secret:\n%s", desiredInputs)
 	}
+
+	if p.clientSecret != "" && usesPrivateKeyJWT {
+		return fmt.Errorf("use either a client secret or a client assertion key, not both")
+	}
+
+	if p.assertionKey != "" && p.assertionKeyPath != "" {
+		return fmt.Errorf("use either a client assertion key or a path to it, not both")
+	}
 	return nil
 }
 
+// privateKeyJWT loads the client assertion key, if any.
+func (p params) privateKeyJWT() (*auth.PrivateKeyJWT, error) {
+	key := p.assertionKey
+	if p.assertionKeyPath != "" {
+		buf, err := ioutil.ReadFile(p.assertionKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client assertion key: %w", err)
+		}
+		key = string(buf)
+	}
+
+	if key == "" {
+		return nil, nil
+	}
+
+	alg := p.assertionAlg
+	if alg == "" {
+		alg = defaultClientAssertionAlg
+	}
+
+	pk := &auth.PrivateKeyJWT{
+		Domain:     p.clientDomain,
+		ClientID:   p.clientID,
+		PrivateKey: key,
+		KeyID:      p.assertionKeyID,
+		Algorithm:  alg,
+	}
+	if err := pk.Validate(); err != nil {
+		return nil, err
+	}
+
+	return pk, nil
+}
+
 func configCmd(cli *cli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:    "config",
@@ -61,6 +130,8 @@ func configCmd(cli *cli) *cobra.Command {
 	}
 
 	cmd.AddCommand(initCmd(cli))
+	cmd.AddCommand(secretsCmd(cli))
+	cmd.AddCommand(networkCmd(cli))
 	return cmd
 }
 
@@ -75,7 +146,16 @@ func initCmd(cli *cli) *cobra.Command {
 			clientSecret := viper.GetString("CLIENT_SECRET")
 
 			cli.setPath(filePath)
-			p := params{filePath, clientDomain, clientID, clientSecret}
+			p := params{
+				filePath:         filePath,
+				clientDomain:     clientDomain,
+				clientID:         clientID,
+				clientSecret:     clientSecret,
+				assertionKey:     os.Getenv(clientAssertionKeyEnv),
+				assertionKeyPath: viper.GetString("CLIENT_ASSERTION_KEY_PATH"),
+				assertionKeyID:   viper.GetString("CLIENT_ASSERTION_KEY_ID"),
+				assertionAlg:     viper.GetString("CLIENT_ASSERTION_ALG"),
+			}
 			if err := p.validate(); err != nil {
 				return err
 			}
@@ -85,6 +165,32 @@ func initCmd(cli *cli) *cobra.Command {
 				return err
 			}
 
+			pk, err := p.privateKeyJWT()
+			if err != nil {
+				return err
+			}
+
+			if pk != nil {
+				t := tenant{
+					Name:                 p.clientDomain,
+					Domain:               p.clientDomain,
+					ClientID:             p.clientID,
+					ClientAssertionKeyID: pk.KeyID,
+					ClientAssertionAlg:   pk.Algorithm,
+				}
+
+				if p.assertionKeyPath != "" {
+					if t.ClientAssertionKeyPath, err = filepath.Abs(p.assertionKeyPath); err != nil {
+						return err
+					}
+				} else {
+					t.ClientAssertionKeyEnv = clientAssertionKeyEnv
+				}
+
+				_, err := cli.renewPrivateKeyJWTToken(command.Context(), t)
+				return err
+			}
+
 			c := &clientcredentials.Config{
 				ClientID:     p.clientID,
 				ClientSecret: p.clientSecret,
@@ -96,7 +202,7 @@ func initCmd(cli *cli) *cobra.Command {
 				},
 			}
 
-			token, err := c.Token(context.Background())
+			token, err := c.Token(context.WithValue(command.Context(), oauth2.HTTPClient, cli.defaultClient()))
 			if err != nil {
 				return err
 			}
@@ -127,6 +233,12 @@ func initCmd(cli *cli) *cobra.Command {
 	_ = viper.BindPFlag("CLIENT_SECRET", flags.Lookup("client-secret"))
 	flags.String("client-domain", "", "Client domain to use to generate token which is set within config")
 	_ = viper.BindPFlag("CLIENT_DOMAIN", flags.Lookup("client-domain"))
+	flags.String("client-assertion-key-path", "", "Path to the private key to authenticate with Private Key JWT instead of a client secret")
+	_ = viper.BindPFlag("CLIENT_ASSERTION_KEY_PATH", flags.Lookup("client-assertion-key-path"))
+	flags.String("client-assertion-key-id", "", "ID of the key to authenticate with Private Key JWT")
+	_ = viper.BindPFlag("CLIENT_ASSERTION_KEY_ID", flags.Lookup("client-assertion-key-id"))
+	flags.String("client-assertion-alg", defaultClientAssertionAlg, "Algorithm to sign the client assertion with: RS256, RS384 or PS256")
+	_ = viper.BindPFlag("CLIENT_ASSERTION_ALG", flags.Lookup("client-assertion-alg"))
 
 	return cmd
 }
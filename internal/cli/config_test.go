@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params params
+		err    string
+	}{
+		{
+			name:   "client secret",
+			params: params{clientDomain: "travel0.auth0.com", clientID: "id", clientSecret: "secret"},
+		},
+		{
+			name:   "client assertion key",
+			params: params{clientDomain: "travel0.auth0.com", clientID: "id", assertionKey: "key"},
+		},
+		{
+			name:   "client assertion key path",
+			params: params{clientDomain: "travel0.auth0.com", clientID: "id", assertionKeyPath: "key.pem"},
+		},
+		{
+			name:   "no credentials",
+			params: params{clientDomain: "travel0.auth0.com", clientID: "id"},
+			err:    "missing client secret:\n" + desiredInputs,
+		},
+		{
+			name:   "both credentials",
+			params: params{clientDomain: "travel0.auth0.com", clientID: "id", clientSecret: "secret", assertionKey: "key"},
+			err:    "use either a client secret or a client assertion key, not both",
+		},
+		{
+			name:   "both key and path",
+			params: params{clientDomain: "travel0.auth0.com", clientID: "id", assertionKey: "key", assertionKeyPath: "key.pem"},
+			err:    "use either a client assertion key or a path to it, not both",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.params.validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestParamsPrivateKeyJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, ioutil.WriteFile(path, p, 0600))
+
+	t.Run("reads the key from a file", func(t *testing.T) {
+		pk, err := params{clientDomain: "travel0.auth0.com", clientID: "id", assertionKeyPath: path, assertionKeyID: "kid"}.privateKeyJWT()
+		require.NoError(t, err)
+		assert.Equal(t, string(p), pk.PrivateKey)
+		assert.Equal(t, "kid", pk.KeyID)
+		assert.Equal(t, "RS256", pk.Algorithm)
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		pk, err := params{clientDomain: "travel0.auth0.com", clientID: "id", clientSecret: "secret"}.privateKeyJWT()
+		assert.NoError(t, err)
+		assert.Nil(t, pk)
+	})
+
+	t.Run("invalid algorithm", func(t *testing.T) {
+		_, err := params{clientDomain: "travel0.auth0.com", clientID: "id", assertionKey: string(p), assertionAlg: "ES256"}.privateKeyJWT()
+		assert.EqualError(t, err, `unsupported signing algorithm "ES256", use one of: RS256, RS384, PS256`)
+	})
+}
+
+func TestTenantPrivateKeyJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, ioutil.WriteFile(path, p, 0600))
+
+	t.Run("reads the key from its file", func(t *testing.T) {
+		tn := tenant{Domain: "travel0.auth0.com", ClientID: "id", ClientAssertionKeyPath: path, ClientAssertionAlg: "RS256"}
+		require.True(t, tn.usesPrivateKeyJWT())
+
+		pk, err := tn.privateKeyJWT()
+		require.NoError(t, err)
+		assert.Equal(t, string(p), pk.PrivateKey)
+	})
+
+	t.Run("reads the key from its env var", func(t *testing.T) {
+		tn := tenant{Domain: "travel0.auth0.com", ClientID: "id", ClientAssertionKeyEnv: "AUTH0_CLI_TEST_ASSERTION_KEY", ClientAssertionAlg: "RS256"}
+
+		_, err := tn.privateKeyJWT()
+		assert.EqualError(t, err, "the client assertion key of travel0.auth0.com is read from AUTH0_CLI_TEST_ASSERTION_KEY, which isn't set")
+
+		os.Setenv("AUTH0_CLI_TEST_ASSERTION_KEY", string(p))
+		defer os.Unsetenv("AUTH0_CLI_TEST_ASSERTION_KEY")
+
+		pk, err := tn.privateKeyJWT()
+		require.NoError(t, err)
+		assert.Equal(t, string(p), pk.PrivateKey)
+	})
+
+	t.Run("client secret", func(t *testing.T) {
+		tn := tenant{Domain: "travel0.auth0.com", ClientID: "id", ClientSecret: "secret"}
+		assert.False(t, tn.usesPrivateKeyJWT())
+	})
+}
@@ -83,6 +83,7 @@ func customDomainsCmd(cli *cli) *cobra.Command {
 	cmd.AddCommand(updateCustomDomainCmd(cli))
 	cmd.AddCommand(deleteCustomDomainCmd(cli))
 	cmd.AddCommand(verifyCustomDomainCmd(cli))
+	cmd.AddCommand(checkCustomDomainCmd(cli))
 
 	return cmd
 }
@@ -412,6 +413,16 @@ func apiTLSPolicyFor(v string) *string {
 }
 
 func (c *cli) customDomainsPickerOptions() (pickerOptions, error) {
+	return c.customDomainsPickerOptionsWithStatus(true)
+}
+
+// allCustomDomainsPickerOptions also lists the custom domains that aren't
+// ready yet, with their status.
+func (c *cli) allCustomDomainsPickerOptions() (pickerOptions, error) {
+	return c.customDomainsPickerOptionsWithStatus(false)
+}
+
+func (c *cli) customDomainsPickerOptionsWithStatus(readyOnly bool) (pickerOptions, error) {
 	var opts pickerOptions
 
 	domains, err := c.api.CustomDomain.List()
@@ -427,12 +438,15 @@ func (c *cli) customDomainsPickerOptions() (pickerOptions, error) {
 	}
 
 	for _, d := range domains {
-		if d.GetStatus() != "ready" {
+		if readyOnly && d.GetStatus() != "ready" {
 			continue
 		}
 
 		value := d.GetID()
 		label := fmt.Sprintf("%s %s", d.GetDomain(), ansi.Faint("("+value+")"))
+		if !readyOnly {
+			label = fmt.Sprintf("%s %s", d.GetDomain(), ansi.Faint("("+value+", "+d.GetStatus()+")"))
+		}
 		opts = append(opts, pickerOption{value: value, label: label})
 	}
 
@@ -0,0 +1,347 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+)
+
+const systemResolver = "system"
+
+var (
+	customDomainResolvers = Flag{
+		Name:     "Resolvers",
+		LongForm: "resolver",
+		Help:     "DNS resolvers to check the records against, as host or host:port. Use 'system' for the resolver of this machine.",
+	}
+
+	customDomainWait = Flag{
+		Name:      "Wait",
+		LongForm:  "wait",
+		ShortForm: "w",
+		Help:      "Wait until the records have propagated and the custom domain is verified.",
+	}
+
+	customDomainInterval = Flag{
+		Name:     "Interval",
+		LongForm: "interval",
+		Help:     "How often to check again while waiting.",
+	}
+
+	customDomainTimeout = Flag{
+		Name:     "Timeout",
+		LongForm: "timeout",
+		Help:     "How long to wait for before giving up.",
+	}
+
+	defaultCustomDomainResolvers = []string{"1.1.1.1", "8.8.8.8"}
+)
+
+// dnsResolver is the subset of net.Resolver the custom domain check relies on.
+type dnsResolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type namedResolver struct {
+	name string
+	dnsResolver
+}
+
+// customDomainRecord is a DNS record Auth0 requires for a custom domain.
+type customDomainRecord struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+func checkCustomDomainCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		ID        string
+		Resolvers []string
+		Wait      bool
+		Interval  time.Duration
+		Timeout   time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Check the DNS records of a custom domain",
+		Long: `Check the DNS records of a custom domain.
+
+The CNAME and TXT records Auth0 requires are looked up on each resolver, and
+every record that is missing or wrong is explained. With --wait, the records
+are checked until they have propagated, and the custom domain is then verified.`,
+		Example: `auth0 branding domains check
+auth0 branding domains check <id>
+auth0 branding domains check <id> --resolver 9.9.9.9 --resolver system
+auth0 branding domains check <id> --wait --interval 1m --timeout 2h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				err := customDomainID.Pick(cmd, &inputs.ID, cli.allCustomDomainsPickerOptions)
+				if err != nil {
+					return err
+				}
+			} else {
+				inputs.ID = args[0]
+			}
+
+			ctx := cmd.Context()
+			resolvers := customDomainResolversFor(inputs.Resolvers)
+
+			var customDomain *management.CustomDomain
+			if err := ansi.Waiting(func() error {
+				var err error
+				customDomain, err = cli.api.CustomDomain.Read(url.PathEscape(inputs.ID))
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get a custom domain with Id '%s': %w", inputs.ID, err)
+			}
+
+			records := customDomainRecords(customDomain)
+			if len(records) == 0 {
+				return fmt.Errorf("The custom domain '%s' has no DNS records to check.", customDomain.GetDomain())
+			}
+
+			var checks []display.CustomDomainRecordCheck
+			_ = ansi.Waiting(func() error {
+				checks = checkCustomDomainRecords(ctx, resolvers, records)
+				return nil
+			})
+			cli.renderer.CustomDomainCheck(customDomain, checks)
+
+			if !inputs.Wait {
+				if !customDomainChecksOK(checks) {
+					return fmt.Errorf("The DNS records of '%s' aren't set up correctly.", customDomain.GetDomain())
+				}
+				return nil
+			}
+
+			deadline := time.Now().Add(inputs.Timeout)
+			sleep := func() error {
+				if time.Now().Add(inputs.Interval).After(deadline) {
+					return fmt.Errorf("gave up after %s", inputs.Timeout)
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(inputs.Interval):
+					return nil
+				}
+			}
+
+			if err := ansi.Spinner("Waiting for the DNS records to propagate", func() error {
+				for !customDomainChecksOK(checks) {
+					if err := sleep(); err != nil {
+						return err
+					}
+					checks = checkCustomDomainRecords(ctx, resolvers, records)
+				}
+				return nil
+			}); err != nil {
+				cli.renderer.CustomDomainCheck(customDomain, checks)
+				return fmt.Errorf("The DNS records of '%s' haven't propagated: %w", customDomain.GetDomain(), err)
+			}
+
+			if err := ansi.Spinner("Verifying the custom domain", func() error {
+				for {
+					var err error
+					if customDomain, err = cli.api.CustomDomain.Verify(url.PathEscape(inputs.ID)); err != nil {
+						return err
+					}
+					if customDomain.GetStatus() == "ready" {
+						return nil
+					}
+					if err := sleep(); err != nil {
+						return err
+					}
+				}
+			}); err != nil {
+				return fmt.Errorf("Unable to verify the custom domain '%s': %w", customDomain.GetDomain(), err)
+			}
+
+			cli.renderer.CustomDomainShow(customDomain)
+			return nil
+		},
+	}
+
+	customDomainResolvers.RegisterStringSlice(cmd, &inputs.Resolvers, defaultCustomDomainResolvers)
+	customDomainWait.RegisterBool(cmd, &inputs.Wait, false)
+	customDomainInterval.RegisterDuration(cmd, &inputs.Interval, 30*time.Second)
+	customDomainTimeout.RegisterDuration(cmd, &inputs.Timeout, time.Hour)
+
+	return cmd
+}
+
+// customDomainRecords reads the records Auth0 expects from the verification
+// methods of the custom domain.
+func customDomainRecords(customDomain *management.CustomDomain) []customDomainRecord {
+	if customDomain.Verification == nil {
+		return nil
+	}
+
+	var records []customDomainRecord
+	for _, method := range customDomain.Verification.Methods {
+		name, _ := method["name"].(string)
+		value, _ := method["record"].(string)
+		if value == "" {
+			continue
+		}
+
+		switch strings.ToLower(name) {
+		case "cname":
+			records = append(records, customDomainRecord{
+				Type:  "CNAME",
+				Name:  customDomain.GetDomain(),
+				Value: value,
+			})
+		case "txt":
+			domain, _ := method["domain"].(string)
+			if domain == "" {
+				domain = "_cf-custom-hostname." + customDomain.GetDomain()
+			}
+			records = append(records, customDomainRecord{
+				Type:  "TXT",
+				Name:  domain,
+				Value: value,
+			})
+		}
+	}
+
+	return records
+}
+
+func customDomainResolversFor(addrs []string) []namedResolver {
+	var resolvers []namedResolver
+
+	for _, addr := range addrs {
+		if addr == systemResolver {
+			resolvers = append(resolvers, namedResolver{addr, net.DefaultResolver})
+			continue
+		}
+
+		target := addr
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			target = net.JoinHostPort(addr, "53")
+		}
+
+		resolvers = append(resolvers, namedResolver{addr, &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, target)
+			},
+		}})
+	}
+
+	return resolvers
+}
+
+func checkCustomDomainRecords(ctx context.Context, resolvers []namedResolver, records []customDomainRecord) []display.CustomDomainRecordCheck {
+	var checks []display.CustomDomainRecordCheck
+
+	for _, r := range resolvers {
+		for _, record := range records {
+			var check display.CustomDomainRecordCheck
+			switch record.Type {
+			case "CNAME":
+				check = checkCNAMERecord(ctx, r, record)
+			case "TXT":
+				check = checkTXTRecord(ctx, r, record)
+			}
+			check.Resolver = r.name
+			checks = append(checks, check)
+		}
+	}
+
+	return checks
+}
+
+func checkCNAMERecord(ctx context.Context, r dnsResolver, record customDomainRecord) display.CustomDomainRecordCheck {
+	check := display.CustomDomainRecordCheck{Type: record.Type, Name: record.Name, Expected: record.Value}
+
+	found, err := r.LookupCNAME(ctx, record.Name)
+	if err != nil {
+		check.Problem = lookupProblem(record, err)
+		return check
+	}
+
+	found = canonicalHost(found)
+	check.Found = []string{found}
+
+	if found == canonicalHost(record.Name) {
+		check.Problem = fmt.Sprintf("%s has no CNAME record, add one pointing to %s.", record.Name, record.Value)
+		return check
+	}
+
+	// The lookup follows the whole chain of CNAME records, so the target
+	// itself may resolve further.
+	expected := canonicalHost(record.Value)
+	if found != expected {
+		if target, err := r.LookupCNAME(ctx, record.Value); err == nil {
+			expected = canonicalHost(target)
+		}
+	}
+
+	if found != expected {
+		check.Problem = fmt.Sprintf("The CNAME record of %s points to %s instead of %s.", record.Name, found, record.Value)
+		return check
+	}
+
+	check.OK = true
+	return check
+}
+
+func checkTXTRecord(ctx context.Context, r dnsResolver, record customDomainRecord) display.CustomDomainRecordCheck {
+	check := display.CustomDomainRecordCheck{Type: record.Type, Name: record.Name, Expected: record.Value}
+
+	found, err := r.LookupTXT(ctx, record.Name)
+	if err != nil {
+		check.Problem = lookupProblem(record, err)
+		return check
+	}
+
+	check.Found = found
+	for _, v := range found {
+		if strings.TrimSpace(v) == record.Value {
+			check.OK = true
+			return check
+		}
+	}
+
+	if len(found) == 0 {
+		check.Problem = fmt.Sprintf("%s has no TXT record, add one with the value %s.", record.Name, record.Value)
+	} else {
+		check.Problem = fmt.Sprintf("None of the TXT records of %s has the value %s.", record.Name, record.Value)
+	}
+	return check
+}
+
+func lookupProblem(record customDomainRecord, err error) string {
+	if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+		return fmt.Sprintf("%s doesn't exist, add a %s record with the value %s.", record.Name, record.Type, record.Value)
+	}
+	return fmt.Sprintf("Unable to look up the %s record of %s: %v", record.Type, record.Name, err)
+}
+
+func customDomainChecksOK(checks []display.CustomDomainRecordCheck) bool {
+	for _, check := range checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
+
+func canonicalHost(v string) string {
+	return strings.TrimSuffix(strings.ToLower(v), ".")
+}
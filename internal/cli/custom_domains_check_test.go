@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	cnames map[string]string
+	txts   map[string][]string
+}
+
+func (r *fakeResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if v, ok := r.cnames[host]; ok {
+		return v, nil
+	}
+	return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if v, ok := r.txts[name]; ok {
+		return v, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestCheckCustomDomainRecords(t *testing.T) {
+	customDomain := &management.CustomDomain{
+		Domain: auth0.String("login.example.com"),
+		Verification: &management.CustomDomainVerification{
+			Methods: []map[string]interface{}{
+				{"name": "cname", "record": "travel0-cd-abc.edge.tenants.auth0.com"},
+				{"name": "txt", "record": "auth0-domain-verification=xyz", "domain": "_cf-custom-hostname.login.example.com"},
+			},
+		},
+	}
+
+	records := customDomainRecords(customDomain)
+	assert.Equal(t, []customDomainRecord{
+		{Type: "CNAME", Name: "login.example.com", Value: "travel0-cd-abc.edge.tenants.auth0.com"},
+		{Type: "TXT", Name: "_cf-custom-hostname.login.example.com", Value: "auth0-domain-verification=xyz"},
+	}, records)
+
+	propagated := &fakeResolver{
+		cnames: map[string]string{
+			"login.example.com":                     "edge.cloudflare.net.",
+			"travel0-cd-abc.edge.tenants.auth0.com": "edge.cloudflare.net.",
+		},
+		txts: map[string][]string{
+			"_cf-custom-hostname.login.example.com": {"other", "auth0-domain-verification=xyz"},
+		},
+	}
+	wrong := &fakeResolver{
+		cnames: map[string]string{"login.example.com": "old.example.net."},
+		txts:   map[string][]string{"_cf-custom-hostname.login.example.com": {"stale"}},
+	}
+	missing := &fakeResolver{}
+
+	checks := checkCustomDomainRecords(context.Background(), []namedResolver{
+		{"propagated", propagated},
+		{"wrong", wrong},
+		{"missing", missing},
+	}, records)
+
+	assert.Len(t, checks, 6)
+	assert.True(t, checks[0].OK)
+	assert.True(t, checks[1].OK)
+	assert.False(t, customDomainChecksOK(checks))
+
+	assert.Equal(t, "The CNAME record of login.example.com points to old.example.net instead of travel0-cd-abc.edge.tenants.auth0.com.", checks[2].Problem)
+	assert.Equal(t, "None of the TXT records of _cf-custom-hostname.login.example.com has the value auth0-domain-verification=xyz.", checks[3].Problem)
+	assert.Equal(t, "login.example.com doesn't exist, add a CNAME record with the value travel0-cd-abc.edge.tenants.auth0.com.", checks[4].Problem)
+	assert.Equal(t, "missing", checks[5].Resolver)
+}
@@ -0,0 +1,711 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/auth0-cli/internal/prompt"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+)
+
+const (
+	emailProviderSMTP      = "smtp"
+	emailProviderSendGrid  = "sendgrid"
+	emailProviderSES       = "ses"
+	emailProviderMailgun   = "mailgun"
+	emailProviderSparkPost = "sparkpost"
+	emailProviderMandrill  = "mandrill"
+)
+
+var (
+	emailProviderName = Flag{
+		Name:       "Provider",
+		LongForm:   "provider",
+		ShortForm:  "p",
+		Help:       "Email provider. Possible values: smtp, sendgrid, ses, mailgun, sparkpost, mandrill.",
+		IsRequired: true,
+	}
+	emailProviderOptions = []string{
+		"SMTP",
+		"SendGrid",
+		"Amazon SES",
+		"Mailgun",
+		"SparkPost",
+		"Mandrill",
+	}
+	emailProviderDefaultFrom = Flag{
+		Name:         "Default From Address",
+		LongForm:     "default-from",
+		ShortForm:    "f",
+		Help:         "Email address used as the sender when a template doesn't set one.",
+		AlwaysPrompt: true,
+	}
+	emailProviderEnabled = Flag{
+		Name:      "Enabled",
+		LongForm:  "enabled",
+		ShortForm: "e",
+		Help:      "Whether the provider is enabled (true) or disabled (false).",
+	}
+	emailProviderAPIKey = Flag{
+		Name:         "API Key",
+		LongForm:     "api-key",
+		Help:         "API key of the SendGrid, Mailgun, SparkPost or Mandrill account.",
+		AlwaysPrompt: true,
+	}
+	emailProviderAccessKeyID = Flag{
+		Name:         "AWS Access Key ID",
+		LongForm:     "access-key-id",
+		Help:         "Access key ID of the AWS user allowed to send emails through Amazon SES.",
+		AlwaysPrompt: true,
+	}
+	emailProviderSecretAccessKey = Flag{
+		Name:         "AWS Secret Access Key",
+		LongForm:     "secret-access-key",
+		Help:         "Secret access key of the AWS user allowed to send emails through Amazon SES.",
+		AlwaysPrompt: true,
+	}
+	emailProviderRegion = Flag{
+		Name:         "Region",
+		LongForm:     "region",
+		Help:         "AWS region of Amazon SES (e.g. us-east-1), or 'eu' for the EU regions of Mailgun and SparkPost.",
+		AlwaysPrompt: true,
+	}
+	emailProviderDomain = Flag{
+		Name:         "Domain",
+		LongForm:     "domain",
+		Help:         "Sending domain of the Mailgun account.",
+		AlwaysPrompt: true,
+	}
+	emailProviderSMTPHost = Flag{
+		Name:         "SMTP Host",
+		LongForm:     "smtp-host",
+		Help:         "Hostname of the SMTP server.",
+		AlwaysPrompt: true,
+	}
+	emailProviderSMTPPort = Flag{
+		Name:         "SMTP Port",
+		LongForm:     "smtp-port",
+		Help:         "Port of the SMTP server, e.g. 25, 465 or 587.",
+		AlwaysPrompt: true,
+	}
+	emailProviderSMTPUser = Flag{
+		Name:         "SMTP Username",
+		LongForm:     "smtp-user",
+		Help:         "Username to authenticate with the SMTP server.",
+		AlwaysPrompt: true,
+	}
+	emailProviderSMTPPass = Flag{
+		Name:         "SMTP Password",
+		LongForm:     "smtp-pass",
+		Help:         "Password to authenticate with the SMTP server.",
+		AlwaysPrompt: true,
+	}
+	emailProviderTestUser = Flag{
+		Name:       "User ID",
+		LongForm:   "user-id",
+		ShortForm:  "u",
+		Help:       "ID of the user the test email is sent to.",
+		IsRequired: true,
+	}
+)
+
+// emailProviderCredentialInputs are the provider specific flags, only some of
+// which apply to each provider.
+type emailProviderCredentialInputs struct {
+	APIKey          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Domain          string
+	SMTPHost        string
+	SMTPPort        string
+	SMTPUser        string
+	SMTPPass        string
+}
+
+func (i *emailProviderCredentialInputs) register(cmd *cobra.Command) {
+	emailProviderAPIKey.RegisterString(cmd, &i.APIKey, "")
+	emailProviderAccessKeyID.RegisterString(cmd, &i.AccessKeyID, "")
+	emailProviderSecretAccessKey.RegisterString(cmd, &i.SecretAccessKey, "")
+	emailProviderRegion.RegisterString(cmd, &i.Region, "")
+	emailProviderDomain.RegisterString(cmd, &i.Domain, "")
+	emailProviderSMTPHost.RegisterString(cmd, &i.SMTPHost, "")
+	emailProviderSMTPPort.RegisterString(cmd, &i.SMTPPort, "")
+	emailProviderSMTPUser.RegisterString(cmd, &i.SMTPUser, "")
+	emailProviderSMTPPass.RegisterString(cmd, &i.SMTPPass, "")
+}
+
+func emailProviderCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider",
+		Short: "Manage the email provider",
+		Long:  "Manage the email provider used to send the tenant's emails.",
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showEmailProviderCmd(cli))
+	cmd.AddCommand(createEmailProviderCmd(cli))
+	cmd.AddCommand(updateEmailProviderCmd(cli))
+	cmd.AddCommand(deleteEmailProviderCmd(cli))
+	cmd.AddCommand(testEmailProviderCmd(cli))
+	return cmd
+}
+
+func showEmailProviderCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show",
+		Args:    cobra.NoArgs,
+		Short:   "Show the email provider",
+		Long:    "Show the email provider.",
+		Example: `auth0 branding emails provider show`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var email *management.Email
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				email, err = cli.api.Email.Read()
+				return err
+			}); err != nil {
+				if isNotFound(err) {
+					cli.renderer.EmptyState("email provider")
+					cli.renderer.Infof("use 'auth0 branding emails provider create' to configure one")
+					return nil
+				}
+				return fmt.Errorf("Unable to get the email provider: %w", err)
+			}
+
+			cli.renderer.EmailProviderShow(email)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func createEmailProviderCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Provider    string
+		DefaultFrom string
+		Enabled     bool
+		Credentials emailProviderCredentialInputs
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Args:  cobra.NoArgs,
+		Short: "Configure the email provider",
+		Long:  "Configure the email provider used to send the tenant's emails.",
+		Example: `auth0 branding emails provider create
+auth0 branding emails provider create -p sendgrid -f no-reply@example.com --api-key <key>
+auth0 branding emails provider create -p smtp --smtp-host smtp.example.com --smtp-port 587 --smtp-user <user> --smtp-pass <password>
+auth0 branding emails provider create -p ses --access-key-id <id> --secret-access-key <secret> --region us-east-1
+auth0 branding emails provider create -p mailgun --api-key <key> --domain mg.example.com --region eu`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := emailProviderName.Select(cmd, &inputs.Provider, emailProviderOptions, nil); err != nil {
+				return err
+			}
+
+			provider := apiEmailProviderFor(inputs.Provider)
+			if !isEmailProvider(provider) {
+				return fmt.Errorf("Unsupported email provider '%s'", inputs.Provider)
+			}
+
+			if err := emailProviderDefaultFrom.Ask(cmd, &inputs.DefaultFrom, nil); err != nil {
+				return err
+			}
+
+			credentials, err := askEmailProviderCredentials(cmd, provider, &inputs.Credentials, nil)
+			if err != nil {
+				return err
+			}
+
+			if err := requireEmailProviderCredentials(provider, credentials); err != nil {
+				return err
+			}
+
+			email := &management.Email{
+				Name:        &provider,
+				Enabled:     &inputs.Enabled,
+				Credentials: credentials,
+			}
+			if inputs.DefaultFrom != "" {
+				email.DefaultFromAddress = &inputs.DefaultFrom
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.Email.Create(email)
+			}); err != nil {
+				return fmt.Errorf("Unable to configure the email provider: %w", err)
+			}
+
+			cli.renderer.EmailProviderCreate(email)
+			return nil
+		},
+	}
+
+	emailProviderName.RegisterString(cmd, &inputs.Provider, "")
+	emailProviderDefaultFrom.RegisterString(cmd, &inputs.DefaultFrom, "")
+	emailProviderEnabled.RegisterBool(cmd, &inputs.Enabled, true)
+	inputs.Credentials.register(cmd)
+
+	return cmd
+}
+
+func updateEmailProviderCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Provider    string
+		DefaultFrom string
+		Enabled     bool
+		Credentials emailProviderCredentialInputs
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Args:  cobra.NoArgs,
+		Short: "Update the email provider",
+		Long: `Update the email provider.
+
+Credentials that aren't given are left unchanged, unless the provider itself
+changes, in which case all of its credentials are required.`,
+		Example: `auth0 branding emails provider update
+auth0 branding emails provider update --enabled=false
+auth0 branding emails provider update --api-key <key>
+auth0 branding emails provider update -p smtp --smtp-host smtp.example.com --smtp-port 587 --smtp-user <user> --smtp-pass <password>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var current *management.Email
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				current, err = cli.api.Email.Read()
+				return err
+			}); err != nil {
+				if isNotFound(err) {
+					return fmt.Errorf("No email provider is configured, use 'auth0 branding emails provider create' to configure one.")
+				}
+				return fmt.Errorf("Unable to get the email provider: %w", err)
+			}
+
+			currentLabel := emailProviderLabelFor(current.GetName())
+			if err := emailProviderName.SelectU(cmd, &inputs.Provider, emailProviderOptions, &currentLabel); err != nil {
+				return err
+			}
+
+			provider := current.GetName()
+			if inputs.Provider != "" {
+				provider = apiEmailProviderFor(inputs.Provider)
+			}
+			if !isEmailProvider(provider) {
+				return fmt.Errorf("Unsupported email provider '%s'", inputs.Provider)
+			}
+
+			if err := emailProviderDefaultFrom.AskU(cmd, &inputs.DefaultFrom, current.DefaultFromAddress); err != nil {
+				return err
+			}
+
+			// Switching providers means starting over with the credentials.
+			var currentCredentials *management.EmailCredentials
+			if provider == current.GetName() {
+				currentCredentials = current.Credentials
+				if currentCredentials == nil {
+					currentCredentials = &management.EmailCredentials{}
+				}
+			}
+
+			credentials, err := askEmailProviderCredentials(cmd, provider, &inputs.Credentials, currentCredentials)
+			if err != nil {
+				return err
+			}
+
+			email := &management.Email{}
+
+			if provider != current.GetName() {
+				if err := requireEmailProviderCredentials(provider, credentials); err != nil {
+					return err
+				}
+				email.Name = &provider
+			}
+
+			if credentials != nil {
+				email.Credentials = credentials
+			}
+
+			if inputs.DefaultFrom != "" {
+				email.DefaultFromAddress = &inputs.DefaultFrom
+			}
+
+			if emailProviderEnabled.IsSet(cmd) {
+				email.Enabled = &inputs.Enabled
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.Email.Update(email)
+			}); err != nil {
+				return fmt.Errorf("Unable to update the email provider: %w", err)
+			}
+
+			// The update payload only holds what changed, so fill in the rest
+			// for display.
+			if email.Name == nil {
+				email.Name = current.Name
+			}
+			if email.Enabled == nil {
+				email.Enabled = current.Enabled
+			}
+			if email.DefaultFromAddress == nil {
+				email.DefaultFromAddress = current.DefaultFromAddress
+			}
+			if email.Credentials == nil {
+				email.Credentials = current.Credentials
+			}
+
+			cli.renderer.EmailProviderUpdate(email)
+			return nil
+		},
+	}
+
+	emailProviderName.RegisterStringU(cmd, &inputs.Provider, "")
+	emailProviderDefaultFrom.RegisterStringU(cmd, &inputs.DefaultFrom, "")
+	emailProviderEnabled.RegisterBoolU(cmd, &inputs.Enabled, true)
+	inputs.Credentials.register(cmd)
+
+	return cmd
+}
+
+func deleteEmailProviderCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Args:  cobra.NoArgs,
+		Short: "Delete the email provider",
+		Long: `Delete the email provider.
+
+The tenant falls back to Auth0's built-in email provider, which is only meant
+for testing.`,
+		Example: `auth0 branding emails provider delete`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cli.force && canPrompt(cmd) {
+				if confirmed := prompt.Confirm("Are you sure you want to proceed?"); !confirmed {
+					return nil
+				}
+			}
+
+			return ansi.Spinner("Deleting email provider", func() error {
+				if err := cli.api.Email.Delete(); err != nil {
+					return fmt.Errorf("Unable to delete the email provider: %w", err)
+				}
+				return nil
+			})
+		},
+	}
+
+	return cmd
+}
+
+func testEmailProviderCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		UserID string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Args:  cobra.NoArgs,
+		Short: "Send a real verification email to a user through the email provider",
+		Long: `Send a real verification email to a user through the email provider.
+
+The user given by --user-id receives an actual verification email, which goes
+through the same provider and templates as every other email of the tenant,
+so pick a user you own. You're asked to confirm unless --force is given.`,
+		Example: `auth0 branding emails provider test --user-id <user-id>
+auth0 branding emails provider test --user-id <user-id> --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputs.UserID == "" {
+				return errors.New("Use --user-id to pick the user the verification email is sent to")
+			}
+
+			var (
+				email *management.Email
+				user  *management.User
+				job   = &management.Job{UserID: &inputs.UserID}
+			)
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				if email, err = cli.api.Email.Read(); err != nil {
+					if isNotFound(err) {
+						return fmt.Errorf("no email provider is configured, use 'auth0 branding emails provider create' to configure one")
+					}
+					return err
+				}
+				user, err = cli.api.User.Read(inputs.UserID)
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to send a test email: %w", err)
+			}
+
+			if !cli.force && canPrompt(cmd) {
+				msg := fmt.Sprintf("A real verification email will be sent to %s. Are you sure you want to proceed?", user.GetEmail())
+				if confirmed := prompt.Confirm(msg); !confirmed {
+					return nil
+				}
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.Jobs.VerifyEmail(job)
+			}); err != nil {
+				return fmt.Errorf("Unable to send a test email: %w", err)
+			}
+
+			if err := ansi.Spinner("Sending test email", func() error {
+				return cli.waitForJob(job, time.Minute)
+			}); err != nil {
+				return fmt.Errorf("Unable to send a test email: %w", err)
+			}
+
+			cli.renderer.Infof("A test email was sent to %s through %s.", user.GetEmail(), emailProviderLabelFor(email.GetName()))
+			cli.renderer.Infof("If it doesn't arrive, look for failed notifications with 'auth0 logs list'.")
+			return nil
+		},
+	}
+
+	emailProviderTestUser.RegisterString(cmd, &inputs.UserID, "")
+
+	return cmd
+}
+
+// waitForJob polls the job until it's done.
+func (c *cli) waitForJob(job *management.Job, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		switch job.GetStatus() {
+		case "completed":
+			return nil
+		case "failed":
+			return fmt.Errorf("job %s failed", job.GetID())
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("job %s is still %s after %s", job.GetID(), job.GetStatus(), timeout)
+		}
+
+		time.Sleep(time.Second)
+
+		var err error
+		if job, err = c.api.Jobs.Read(job.GetID()); err != nil {
+			return err
+		}
+	}
+}
+
+// askEmailProviderCredentials prompts for the credentials of the provider. On
+// update, current holds the credentials being replaced and nil is returned if
+// none of them changed.
+func askEmailProviderCredentials(cmd *cobra.Command, provider string, inputs *emailProviderCredentialInputs, current *management.EmailCredentials) (*management.EmailCredentials, error) {
+	isUpdate := current != nil
+	if current == nil {
+		current = &management.EmailCredentials{}
+	}
+
+	ask := func(f *Flag, value *string, defaultValue *string) error {
+		if isUpdate {
+			return f.AskU(cmd, value, defaultValue)
+		}
+		return f.Ask(cmd, value, defaultValue)
+	}
+
+	askPassword := func(f *Flag, value *string) error {
+		if isUpdate {
+			return f.AskPasswordU(cmd, value, nil)
+		}
+		return f.AskPassword(cmd, value, nil)
+	}
+
+	var err error
+	switch provider {
+	case emailProviderSMTP:
+		var port *string
+		if current.SMTPPort != nil {
+			port = auth0.String(strconv.Itoa(current.GetSMTPPort()))
+		}
+		if err = ask(&emailProviderSMTPHost, &inputs.SMTPHost, current.SMTPHost); err != nil {
+			return nil, err
+		}
+		if err = ask(&emailProviderSMTPPort, &inputs.SMTPPort, port); err != nil {
+			return nil, err
+		}
+		if err = ask(&emailProviderSMTPUser, &inputs.SMTPUser, current.SMTPUser); err != nil {
+			return nil, err
+		}
+		err = askPassword(&emailProviderSMTPPass, &inputs.SMTPPass)
+
+	case emailProviderSES:
+		if err = ask(&emailProviderAccessKeyID, &inputs.AccessKeyID, current.AccessKeyID); err != nil {
+			return nil, err
+		}
+		if err = askPassword(&emailProviderSecretAccessKey, &inputs.SecretAccessKey); err != nil {
+			return nil, err
+		}
+		err = ask(&emailProviderRegion, &inputs.Region, current.Region)
+
+	case emailProviderMailgun:
+		if err = askPassword(&emailProviderAPIKey, &inputs.APIKey); err != nil {
+			return nil, err
+		}
+		if err = ask(&emailProviderDomain, &inputs.Domain, current.Domain); err != nil {
+			return nil, err
+		}
+		err = ask(&emailProviderRegion, &inputs.Region, current.Region)
+
+	case emailProviderSparkPost:
+		if err = askPassword(&emailProviderAPIKey, &inputs.APIKey); err != nil {
+			return nil, err
+		}
+		err = ask(&emailProviderRegion, &inputs.Region, current.Region)
+
+	case emailProviderSendGrid, emailProviderMandrill:
+		err = askPassword(&emailProviderAPIKey, &inputs.APIKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return emailProviderCredentials(provider, inputs, current, isUpdate)
+}
+
+// emailProviderCredentials builds the credentials of the provider from the
+// inputs. On update, the values that weren't given are taken from current,
+// except for secrets which the management API never returns.
+func emailProviderCredentials(provider string, inputs *emailProviderCredentialInputs, current *management.EmailCredentials, isUpdate bool) (*management.EmailCredentials, error) {
+	changed := false
+	value := func(input string, fallback *string) *string {
+		if input != "" {
+			changed = true
+			return auth0.String(input)
+		}
+		return fallback
+	}
+	secret := func(input string) *string {
+		return value(input, nil)
+	}
+
+	credentials := &management.EmailCredentials{}
+
+	switch provider {
+	case emailProviderSMTP:
+		credentials.SMTPHost = value(inputs.SMTPHost, current.SMTPHost)
+		credentials.SMTPUser = value(inputs.SMTPUser, current.SMTPUser)
+		credentials.SMTPPass = secret(inputs.SMTPPass)
+		credentials.SMTPPort = current.SMTPPort
+		if inputs.SMTPPort != "" {
+			port, err := strconv.Atoi(inputs.SMTPPort)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid SMTP port '%s'", inputs.SMTPPort)
+			}
+			credentials.SMTPPort = auth0.Int(port)
+			changed = true
+		}
+
+	case emailProviderSES:
+		credentials.AccessKeyID = value(inputs.AccessKeyID, current.AccessKeyID)
+		credentials.SecretAccessKey = secret(inputs.SecretAccessKey)
+		credentials.Region = value(inputs.Region, current.Region)
+
+	case emailProviderMailgun:
+		credentials.APIKey = secret(inputs.APIKey)
+		credentials.Domain = value(inputs.Domain, current.Domain)
+		credentials.Region = value(inputs.Region, current.Region)
+
+	case emailProviderSparkPost:
+		credentials.APIKey = secret(inputs.APIKey)
+		credentials.Region = value(inputs.Region, current.Region)
+
+	case emailProviderSendGrid, emailProviderMandrill:
+		credentials.APIKey = secret(inputs.APIKey)
+	}
+
+	if isUpdate && !changed {
+		return nil, nil
+	}
+
+	return credentials, nil
+}
+
+// requireEmailProviderCredentials checks that every credential the provider
+// needs is set.
+func requireEmailProviderCredentials(provider string, c *management.EmailCredentials) error {
+	var missing []string
+	check := func(f Flag, ok bool) {
+		if !ok {
+			missing = append(missing, "--"+f.LongForm)
+		}
+	}
+
+	switch provider {
+	case emailProviderSMTP:
+		check(emailProviderSMTPHost, c.GetSMTPHost() != "")
+		check(emailProviderSMTPPort, c.GetSMTPPort() != 0)
+		check(emailProviderSMTPUser, c.GetSMTPUser() != "")
+		check(emailProviderSMTPPass, c.GetSMTPPass() != "")
+	case emailProviderSES:
+		check(emailProviderAccessKeyID, c.GetAccessKeyID() != "")
+		check(emailProviderSecretAccessKey, c.GetSecretAccessKey() != "")
+		check(emailProviderRegion, c.GetRegion() != "")
+	case emailProviderMailgun:
+		check(emailProviderAPIKey, c.GetAPIKey() != "")
+		check(emailProviderDomain, c.GetDomain() != "")
+	default:
+		check(emailProviderAPIKey, c.GetAPIKey() != "")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("Missing credentials for %s: %s", emailProviderLabelFor(provider), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	mErr, ok := err.(management.Error)
+	return ok && mErr.Status() == 404
+}
+
+func isEmailProvider(v string) bool {
+	switch v {
+	case emailProviderSMTP, emailProviderSendGrid, emailProviderSES, emailProviderMailgun, emailProviderSparkPost, emailProviderMandrill:
+		return true
+	default:
+		return false
+	}
+}
+
+func apiEmailProviderFor(v string) string {
+	switch strings.ToLower(v) {
+	case "smtp":
+		return emailProviderSMTP
+	case "sendgrid":
+		return emailProviderSendGrid
+	case "ses", "amazon ses":
+		return emailProviderSES
+	case "mailgun":
+		return emailProviderMailgun
+	case "sparkpost":
+		return emailProviderSparkPost
+	case "mandrill":
+		return emailProviderMandrill
+	default:
+		return v
+	}
+}
+
+func emailProviderLabelFor(v string) string {
+	for _, label := range emailProviderOptions {
+		if apiEmailProviderFor(label) == v {
+			return label
+		}
+	}
+	return v
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailProviderCredentials(t *testing.T) {
+	t.Run("create requires the provider credentials", func(t *testing.T) {
+		inputs := &emailProviderCredentialInputs{SMTPHost: "smtp.example.com", SMTPPort: "587", SMTPUser: "jane"}
+
+		c, err := emailProviderCredentials(emailProviderSMTP, inputs, &management.EmailCredentials{}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, 587, c.GetSMTPPort())
+		assert.EqualError(t, requireEmailProviderCredentials(emailProviderSMTP, c), "Missing credentials for SMTP: --smtp-pass")
+
+		inputs.SMTPPass = "secret"
+		c, err = emailProviderCredentials(emailProviderSMTP, inputs, &management.EmailCredentials{}, false)
+		assert.NoError(t, err)
+		assert.NoError(t, requireEmailProviderCredentials(emailProviderSMTP, c))
+	})
+
+	t.Run("update keeps the current values", func(t *testing.T) {
+		current := &management.EmailCredentials{Domain: auth0.String("mg.example.com"), Region: auth0.String("eu")}
+
+		c, err := emailProviderCredentials(emailProviderMailgun, &emailProviderCredentialInputs{}, current, true)
+		assert.NoError(t, err)
+		assert.Nil(t, c)
+
+		c, err = emailProviderCredentials(emailProviderMailgun, &emailProviderCredentialInputs{APIKey: "key"}, current, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "key", c.GetAPIKey())
+		assert.Equal(t, "mg.example.com", c.GetDomain())
+		assert.Equal(t, "eu", c.GetRegion())
+	})
+
+	t.Run("invalid port", func(t *testing.T) {
+		_, err := emailProviderCredentials(emailProviderSMTP, &emailProviderCredentialInputs{SMTPPort: "smtp"}, &management.EmailCredentials{}, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestAPIEmailProviderFor(t *testing.T) {
+	assert.Equal(t, emailProviderSES, apiEmailProviderFor("Amazon SES"))
+	assert.Equal(t, emailProviderSendGrid, apiEmailProviderFor("sendgrid"))
+	assert.Equal(t, "SparkPost", emailProviderLabelFor(emailProviderSparkPost))
+	assert.False(t, isEmailProvider("postmark"))
+}
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/branding"
 	"github.com/spf13/cobra"
 	"github.com/auth0/go-auth0"
 	"github.com/auth0/go-auth0/management"
@@ -82,6 +87,30 @@ var (
 		Help:      "Lifetime in seconds that the link within the email will be valid for.",
 	}
 
+	emailTemplateFile = Flag{
+		Name:     "Template File",
+		LongForm: "file",
+		Help:     "Path to a local Liquid body to preview. When set, the tenant isn't contacted.",
+	}
+
+	emailTemplateVars = Flag{
+		Name:     "Variables File",
+		LongForm: "vars",
+		Help:     "Path to a JSON file with variables overriding the sample ones, e.g. {\"user\": {\"name\": \"John\"}}.",
+	}
+
+	emailTemplateSMTP = Flag{
+		Name:     "SMTP Sink",
+		LongForm: "smtp",
+		Help:     "Address of a local SMTP sink (e.g. localhost:1025) to send the rendered email to every time it changes.",
+	}
+
+	emailTemplateTo = Flag{
+		Name:     "To",
+		LongForm: "to",
+		Help:     "Recipient of the emails sent to the SMTP sink. Defaults to the sample user's email.",
+	}
+
 	emailTemplateOptions = pickerOptions{
 		{"Verification Email (using Link)", emailTemplateVerifyLink},
 		{"Verification Email (using Code)", emailTemplateVerifyCode},
@@ -262,6 +291,175 @@ auth0 branding emails update welcome`,
 	return cmd
 }
 
+func previewEmailTemplateCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Template string
+		File     string
+		From     string
+		Subject  string
+		Vars     string
+		SMTP     string
+		To       string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Preview an email template with sample data",
+		Long: `Preview an email template rendered with sample user, application and tenant data.
+
+The rendered email is served on a local server and opened in the browser. The
+preview reloads as soon as the template file changes: edit the file given with
+--file, or the temporary copy of the tenant's template, to see your changes.`,
+		Example: `auth0 branding emails preview <template>
+auth0 branding emails preview welcome
+auth0 branding emails preview welcome --file welcome.html --subject "Welcome to {{ friendly_name }}"
+auth0 branding emails preview welcome --vars user.json --smtp localhost:1025`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				err := emailTemplateTemplate.Pick(cmd, &inputs.Template, cli.emailTemplatePickerOptions)
+				if err != nil {
+					return err
+				}
+			} else {
+				inputs.Template = args[0]
+			}
+
+			domain := "my-tenant.auth0.com"
+			vars := branding.SampleEmailVariables("My Tenant", domain)
+			filename := inputs.File
+
+			if inputs.File == "" {
+				var (
+					email  *management.EmailTemplate
+					tenant *management.Tenant
+				)
+
+				if err := ansi.Waiting(func() error {
+					var err error
+					if email, err = cli.api.EmailTemplate.Read(apiEmailTemplateFor(inputs.Template)); err != nil {
+						return err
+					}
+					tenant, err = cli.api.Tenant.Read()
+					return err
+				}); err != nil {
+					return fmt.Errorf("Unable to get the email template '%s': %w", inputs.Template, err)
+				}
+
+				if email.GetBody() == "" {
+					return fmt.Errorf("The email template '%s' has no body, use 'auth0 branding emails update' to customize it.", inputs.Template)
+				}
+
+				domain = cli.tenant
+				vars = branding.SampleEmailVariables(tenant.GetFriendlyName(), domain)
+				if inputs.From == "" {
+					inputs.From = email.GetFrom()
+				}
+				if inputs.Subject == "" {
+					inputs.Subject = email.GetSubject()
+				}
+
+				dir, err := ioutil.TempDir("", "auth0-email-preview")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+
+				filename = filepath.Join(dir, inputs.Template+".html")
+				if err := ioutil.WriteFile(filename, []byte(email.GetBody()), 0600); err != nil {
+					return err
+				}
+			}
+
+			if inputs.Vars != "" {
+				buf, err := ioutil.ReadFile(inputs.Vars)
+				if err != nil {
+					return fmt.Errorf("Unable to read the variables file: %w", err)
+				}
+
+				var overrides map[string]interface{}
+				if err := json.Unmarshal(buf, &overrides); err != nil {
+					return fmt.Errorf("Unable to parse the variables file: %w", err)
+				}
+				mergeEmailVariables(vars, overrides)
+			}
+
+			if inputs.From == "" {
+				inputs.From = fmt.Sprintf("no-reply@%s", domain)
+			}
+			if inputs.Subject == "" {
+				inputs.Subject = emailTemplateLabelFor(inputs.Template)
+			}
+			if inputs.To == "" {
+				if user, ok := vars["user"].(map[string]interface{}); ok {
+					inputs.To, _ = user["email"].(string)
+				}
+			}
+
+			data := branding.EmailData{
+				Filename:  filename,
+				Template:  inputs.Template,
+				From:      inputs.From,
+				Subject:   inputs.Subject,
+				Variables: vars,
+				SMTPAddr:  inputs.SMTP,
+				To:        inputs.To,
+			}
+
+			if _, err := branding.RenderEmail(data); err != nil {
+				return fmt.Errorf("Unable to render the email template: %w", err)
+			}
+
+			cli.renderer.Infof("Previewing the '%s' email template. Edit %s to see your changes, CTRL+C to stop.", inputs.Template, filename)
+
+			onSent := func(err error) {
+				if err != nil {
+					cli.renderer.Warnf("Unable to send the email to %s: %v", inputs.SMTP, err)
+					return
+				}
+				cli.renderer.Infof("Email sent to %s through %s", inputs.To, inputs.SMTP)
+			}
+
+			if err := branding.PreviewEmailTemplate(cmd.Context(), data, onSent); err != nil {
+				return fmt.Errorf("Unexpected error while previewing the email template: %w", err)
+			}
+			return nil
+		},
+	}
+
+	emailTemplateFile.RegisterString(cmd, &inputs.File, "")
+	emailTemplateFrom.RegisterString(cmd, &inputs.From, "")
+	emailTemplateSubject.RegisterString(cmd, &inputs.Subject, "")
+	emailTemplateVars.RegisterString(cmd, &inputs.Vars, "")
+	emailTemplateSMTP.RegisterString(cmd, &inputs.SMTP, "")
+	emailTemplateTo.RegisterString(cmd, &inputs.To, "")
+
+	return cmd
+}
+
+// mergeEmailVariables deep merges the overrides into the sample variables, so
+// a single nested value can be changed without repeating its siblings.
+func mergeEmailVariables(vars, overrides map[string]interface{}) {
+	for k, v := range overrides {
+		src, srcOK := v.(map[string]interface{})
+		dst, dstOK := vars[k].(map[string]interface{})
+		if srcOK && dstOK {
+			mergeEmailVariables(dst, src)
+			continue
+		}
+		vars[k] = v
+	}
+}
+
+func emailTemplateLabelFor(v string) string {
+	for _, o := range emailTemplateOptions {
+		if o.value == v {
+			return o.label
+		}
+	}
+	return v
+}
+
 func (c *cli) emailTemplateEditorHint() {
 	c.renderer.Infof("%s once you close the editor, the email template will be saved. To cancel, CTRL+C.", ansi.Faint("Hint:"))
 }
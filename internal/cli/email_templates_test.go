@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeEmailVariables(t *testing.T) {
+	vars := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":  "Jane Doe",
+			"email": "jane.doe@example.com",
+		},
+		"code": "123456",
+	}
+
+	mergeEmailVariables(vars, map[string]interface{}{
+		"user": map[string]interface{}{"name": "John Doe"},
+		"code": "654321",
+		"url":  "https://example.com",
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":  "John Doe",
+			"email": "jane.doe@example.com",
+		},
+		"code": "654321",
+		"url":  "https://example.com",
+	}, vars)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/auth0/auth0-cli/internal/ansi"
 	"github.com/auth0/auth0-cli/internal/auth0"
@@ -129,6 +130,14 @@ func (f *Flag) RegisterIntU(cmd *cobra.Command, value *int, defaultValue int) {
 	registerInt(cmd, f, value, defaultValue, true)
 }
 
+func (f *Flag) RegisterDuration(cmd *cobra.Command, value *time.Duration, defaultValue time.Duration) {
+	registerDuration(cmd, f, value, defaultValue, false)
+}
+
+func (f *Flag) RegisterDurationU(cmd *cobra.Command, value *time.Duration, defaultValue time.Duration) {
+	registerDuration(cmd, f, value, defaultValue, true)
+}
+
 func (f *Flag) RegisterBool(cmd *cobra.Command, value *bool, defaultValue bool) {
 	registerBool(cmd, f, value, defaultValue, false)
 }
@@ -256,6 +265,14 @@ func registerInt(cmd *cobra.Command, f *Flag, value *int, defaultValue int, isUp
 	}
 }
 
+func registerDuration(cmd *cobra.Command, f *Flag, value *time.Duration, defaultValue time.Duration, isUpdate bool) {
+	cmd.Flags().DurationVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
+
+	if err := markFlagRequired(cmd, f, isUpdate); err != nil {
+		panic(auth0.Error(err, "failed to register duration flag"))
+	}
+}
+
 func registerBool(cmd *cobra.Command, f *Flag, value *bool, defaultValue bool, isUpdate bool) {
 	cmd.Flags().BoolVarP(value, f.LongForm, f.ShortForm, defaultValue, f.Help)
 
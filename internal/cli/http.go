@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/buildinfo"
+	"github.com/auth0/auth0-cli/internal/transport"
+)
+
+// networkOptions returns how to connect to servers, as set by the flags, the
+// AUTH0_CA_FILE, AUTH0_CLIENT_CERT and AUTH0_CLIENT_KEY env vars, or the config,
+// in that order.
+func (c *cli) networkOptions() transport.Options {
+	return transport.Options{
+		CAFile:   firstNonEmpty(c.caFile, os.Getenv("AUTH0_CA_FILE"), c.config.CAFile),
+		CertFile: firstNonEmpty(c.clientCert, os.Getenv("AUTH0_CLIENT_CERT"), c.config.ClientCert),
+		KeyFile:  firstNonEmpty(c.clientKey, os.Getenv("AUTH0_CLIENT_KEY"), c.config.ClientKey),
+	}
+}
+
+// configureNetwork sets up the transport all the requests are sent through,
+// which needs the config to be loaded.
+func (c *cli) configureNetwork() error {
+	t, err := transport.New(c.networkOptions())
+	if err != nil {
+		return fmt.Errorf("Unable to configure the network: %w", err)
+	}
+	c.baseTransport = t
+
+	switch {
+	case c.recordDir != "" && c.replayDir != "":
+		return errors.New("Use either --record or --replay")
+	case c.replayDir != "":
+		r, err := transport.NewReplay(c.replayDir)
+		if err != nil {
+			return fmt.Errorf("Unable to replay the requests: %w", err)
+		}
+		c.fixtures = r
+	case c.recordDir != "":
+		c.fixtures = &transport.Record{Base: t, Dir: c.recordDir}
+	}
+
+	return nil
+}
+
+// netTransport returns the transport connecting to servers, before any
+// tracing or retries.
+func (c *cli) netTransport() http.RoundTripper {
+	return c.tlsTransport()
+}
+
+// tlsTransport returns the transport connecting to servers, for the checks
+// which inspect the TLS connections themselves.
+func (c *cli) tlsTransport() *http.Transport {
+	if c.baseTransport == nil {
+		return http.DefaultTransport.(*http.Transport)
+	}
+	return c.baseTransport
+}
+
+// httpTransport returns the transport every request of the CLI is sent
+// through, which traces them in debug mode and to the --trace-file.
+func (c *cli) httpTransport() http.RoundTripper {
+	return c.traced(c.netTransport())
+}
+
+// managementTransport returns the transport of the Management API requests,
+// which are also recorded or replayed with --record or --replay. Other
+// requests, e.g. logins or webhooks, are always sent.
+func (c *cli) managementTransport() http.RoundTripper {
+	if c.fixtures == nil {
+		return c.httpTransport()
+	}
+	return c.traced(c.fixtures)
+}
+
+func (c *cli) traced(base http.RoundTripper) http.RoundTripper {
+	if !c.debug && c.traceFile == "" {
+		return base
+	}
+
+	t := &transport.Trace{Base: base}
+	if c.debug {
+		t.Log = c.renderer.MessageWriter
+	}
+	if c.traceFile != "" {
+		t.HAR = c.traceHAR()
+	}
+	return t
+}
+
+// traceHAR returns the archive the requests are traced to for the --trace-file.
+func (c *cli) traceHAR() *transport.HAR {
+	if c.har == nil {
+		c.har = &transport.HAR{
+			Creator: "auth0-cli",
+			Version: strings.TrimPrefix(buildinfo.Version, "v"),
+		}
+	}
+	return c.har
+}
+
+// httpClient returns the client the Management API is called with, which
+// retries the requests that are rate limited or fail transiently.
+func (c *cli) httpClient() *http.Client {
+	return &http.Client{
+		Transport: &transport.RateLimit{
+			Base:    c.managementTransport(),
+			OnQuota: c.debugQuota,
+		},
+	}
+}
+
+// defaultClient returns the client of the requests which aren't sent to the
+// Management API, e.g. to the Authentication API or the quickstart downloads.
+func (c *cli) defaultClient() *http.Client {
+	return &http.Client{Transport: c.httpTransport()}
+}
+
+// debugQuota shows the remaining rate limit quota in debug mode.
+func (c *cli) debugQuota(q transport.Quota) {
+	if !c.debug {
+		return
+	}
+
+	if q.Reset.IsZero() {
+		c.renderer.Infof("%s %d of %d requests remaining", ansi.Faint("Rate limit:"), q.Remaining, q.Limit)
+		return
+	}
+
+	c.renderer.Infof("%s %d of %d requests remaining, resets in %s",
+		ansi.Faint("Rate limit:"), q.Remaining, q.Limit, time.Until(q.Reset).Round(time.Second))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// writeTraceFile writes the requests traced to the --trace-file.
+func (c *cli) writeTraceFile() {
+	if c.traceFile == "" {
+		return
+	}
+
+	har := c.traceHAR()
+	if err := har.WriteFile(c.traceFile); err != nil {
+		c.renderer.Warnf("Unable to write the trace file: %s", err)
+		return
+	}
+
+	c.renderer.Infof("%d request(s) traced to %s", har.Len(), c.traceFile)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/auth0-cli/internal/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkOptions(t *testing.T) {
+	c := &cli{config: config{CAFile: "config-ca.pem", ClientCert: "config-cert.pem", ClientKey: "config-key.pem"}}
+
+	assert.Equal(t, transport.Options{
+		CAFile:   "config-ca.pem",
+		CertFile: "config-cert.pem",
+		KeyFile:  "config-key.pem",
+	}, c.networkOptions())
+
+	os.Setenv("AUTH0_CA_FILE", "env-ca.pem")
+	os.Setenv("AUTH0_CLIENT_KEY", "env-key.pem")
+	defer os.Unsetenv("AUTH0_CA_FILE")
+	defer os.Unsetenv("AUTH0_CLIENT_KEY")
+
+	c.clientKey = "flag-key.pem"
+
+	assert.Equal(t, transport.Options{
+		CAFile:   "env-ca.pem",
+		CertFile: "config-cert.pem",
+		KeyFile:  "flag-key.pem",
+	}, c.networkOptions())
+}
+
+func TestConfigureNetworkFixtures(t *testing.T) {
+	t.Run("records the requests", func(t *testing.T) {
+		c := &cli{recordDir: t.TempDir()}
+		require.NoError(t, c.configureNetwork())
+		assert.IsType(t, &transport.Record{}, c.managementTransport())
+		assert.IsType(t, &http.Transport{}, c.httpTransport(), "only the Management API requests are recorded")
+	})
+
+	t.Run("fails without fixtures to replay", func(t *testing.T) {
+		c := &cli{replayDir: t.TempDir()}
+		assert.Error(t, c.configureNetwork())
+	})
+
+	t.Run("fails when both recording and replaying", func(t *testing.T) {
+		c := &cli{recordDir: t.TempDir(), replayDir: t.TempDir()}
+		assert.EqualError(t, c.configureNetwork(), "Use either --record or --replay")
+	})
+}
+
+func TestSetupReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"client_id":"abc","name":"travel0"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	// Recorded as sent by the Management API client, which sends a body
+	// with every request.
+	dir := t.TempDir()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v2/clients/abc", strings.NewReader("null"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := (&http.Client{Transport: &transport.Record{Dir: dir}}).Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	c := &cli{replayDir: dir, path: path, renderer: &display.Renderer{}}
+	require.NoError(t, c.configureNetwork())
+	require.NoError(t, c.setup(context.Background(), nil))
+	assert.Equal(t, replayTenant, c.tenant)
+
+	client, err := c.api.Client.Read("abc")
+	require.NoError(t, err)
+	assert.Equal(t, "travel0", client.GetName())
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "nothing is saved")
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+const (
+	logStreamFormatJSONLines  = "JSONLINES"
+	logStreamFormatJSONArray  = "JSONARRAY"
+	logStreamFormatJSONObject = "JSONOBJECT"
+)
+
+// logStreamEvent is how an http log stream wraps each log.
+type logStreamEvent struct {
+	LogID string          `json:"log_id"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// parseLogStreamPayload reads the logs from the body of a log stream request,
+// which must be in the given content format if there's one. Logs that aren't
+// wrapped in a log stream event are accepted too.
+func parseLogStreamPayload(format string, body []byte) ([]*management.Log, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	isArray := body[0] == '['
+	if format == logStreamFormatJSONArray && !isArray {
+		return nil, fmt.Errorf("expected a JSON array")
+	}
+	if format != "" && format != logStreamFormatJSONArray && isArray {
+		return nil, fmt.Errorf("unexpected JSON array, expected %s content", strings.ToLower(format))
+	}
+
+	var events []json.RawMessage
+	if isArray {
+		if err := json.Unmarshal(body, &events); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		for {
+			var event json.RawMessage
+			if err := dec.Decode(&event); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("invalid JSON: %w", err)
+			}
+			events = append(events, event)
+		}
+	}
+
+	if format == logStreamFormatJSONObject && len(events) > 1 {
+		return nil, fmt.Errorf("expected a single JSON object, got %d", len(events))
+	}
+
+	logs := make([]*management.Log, 0, len(events))
+	for _, raw := range events {
+		var event logStreamEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return nil, fmt.Errorf("invalid log: %w", err)
+		}
+
+		// Logs that aren't wrapped in an event, e.g. the ones saved with
+		// 'auth0 logs list --format json', are read as they are.
+		data := event.Data
+		if len(data) == 0 {
+			data = raw
+		}
+
+		var l management.Log
+		if err := json.Unmarshal(data, &l); err != nil {
+			return nil, fmt.Errorf("invalid log %s: %w", event.LogID, err)
+		}
+		if l.LogID == nil && event.LogID != "" {
+			l.LogID = &event.LogID
+		}
+		logs = append(logs, &l)
+	}
+
+	return logs, nil
+}
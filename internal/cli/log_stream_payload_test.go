@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogStreamPayload(t *testing.T) {
+	event := `{"log_id": "90020211201", "data": {"type": "s", "description": "Login", "client_name": "My App"}}`
+
+	tests := []struct {
+		name   string
+		format string
+		body   string
+		want   int
+		err    bool
+	}{
+		{"json lines", logStreamFormatJSONLines, event + "\n" + event + "\n", 2, false},
+		{"json array", logStreamFormatJSONArray, "[" + event + "," + event + "]", 2, false},
+		{"json object", logStreamFormatJSONObject, event, 1, false},
+		{"detected", "", "[" + event + "]", 1, false},
+		{"single json line", logStreamFormatJSONLines, event, 1, false},
+		{"array instead of lines", logStreamFormatJSONLines, "[" + event + "]", 0, true},
+		{"lines instead of object", logStreamFormatJSONObject, event + "\n" + event, 0, true},
+		{"invalid", "", "{", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logs, err := parseLogStreamPayload(test.format, []byte(test.body))
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, logs, test.want)
+			assert.Equal(t, "90020211201", logs[0].GetLogID())
+			assert.Equal(t, "s", logs[0].GetType())
+		})
+	}
+}
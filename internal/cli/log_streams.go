@@ -159,6 +159,10 @@ func logStreamsCmd(cli *cli) *cobra.Command {
 	cmd.AddCommand(updateLogStreamCmd(cli))
 	cmd.AddCommand(deleteLogStreamCmd(cli))
 	cmd.AddCommand(openLogStreamsCmd(cli))
+	cmd.AddCommand(statusLogStreamCmd(cli))
+	cmd.AddCommand(pauseLogStreamCmd(cli))
+	cmd.AddCommand(resumeLogStreamCmd(cli))
+	cmd.AddCommand(listenLogStreamCmd(cli))
 
 	return cmd
 }
@@ -252,6 +256,7 @@ func createLogStreamCmd(cli *cli) *cobra.Command {
 		AzureSubscriptionID string
 		AzureRegion         string
 		AzureResourceGroup  string
+		Filters             []string
 	}
 
 	cmd := &cobra.Command{
@@ -263,8 +268,14 @@ func createLogStreamCmd(cli *cli) *cobra.Command {
 auth0 logs streams create -n mylogstream -t http --http-type application/json --http-format JSONLINES --http-auth 1343434
 auth0 logs streams create -n mydatadog -t datadog --datadog-key 9999999 --datadog-id us
 auth0 logs streams create -n myeventbridge -t eventbridge --eventbridge-id 999999999999 --eventbridge-region us-east-1
-auth0 logs streams create -n test-splunk -t splunk --splunk-domain demo.splunk.com --splunk-token 12a34ab5-c6d7-8901-23ef-456b7c89d0c1 --splunk-port 8080 --splunk-secure=true`,
+auth0 logs streams create -n test-splunk -t splunk --splunk-domain demo.splunk.com --splunk-token 12a34ab5-c6d7-8901-23ef-456b7c89d0c1 --splunk-port 8080 --splunk-secure=true
+auth0 logs streams create -n mysiem -t http --http-endpoint https://siem.example.com/auth0 --filters auth.login.fail,auth.signup.fail`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			filters, err := parseLogStreamFilters(inputs.Filters)
+			if err != nil {
+				return err
+			}
+
 			// Prompt for log stream name
 			if err := logStreamName.Ask(cmd, &inputs.Name, nil); err != nil {
 				return err
@@ -411,6 +422,18 @@ auth0 logs streams create -n test-splunk -t splunk --splunk-domain demo.splunk.c
 				return fmt.Errorf("Unable to create log stream: %v", err)
 			}
 
+			// The filters are set once the stream exists, so failing to set
+			// them leaves a stream receiving every log behind.
+			if logStreamFilters.IsSet(cmd) {
+				if err := ansi.Waiting(func() error {
+					return cli.api.LogStreamFilters.Update(ls.GetID(), filters)
+				}); err != nil {
+					cli.renderer.LogStreamCreate(ls)
+					return fmt.Errorf("Log stream %s was created, but setting its filters failed: %v. "+
+						"It receives every log until they're set with 'auth0 logs streams update %s --filters'", ls.GetID(), err, ls.GetID())
+				}
+			}
+
 			// Render log stream creation specific view
 			cli.renderer.LogStreamCreate(ls)
 			return nil
@@ -435,6 +458,7 @@ auth0 logs streams create -n test-splunk -t splunk --splunk-domain demo.splunk.c
 	azureSubscriptionID.RegisterString(cmd, &inputs.AzureSubscriptionID, "")
 	azureRegion.RegisterString(cmd, &inputs.AzureRegion, "")
 	azureResourceGroup.RegisterString(cmd, &inputs.AzureResourceGroup, "")
+	logStreamFilters.RegisterStringSlice(cmd, &inputs.Filters, nil)
 
 	return cmd
 }
@@ -456,6 +480,7 @@ func updateLogStreamCmd(cli *cli) *cobra.Command {
 		SumoLogicSource   string
 		DatadogAPIKey     string
 		DatadogRegion     string
+		Filters           []string
 	}
 
 	cmd := &cobra.Command{
@@ -468,10 +493,17 @@ auth0 logs streams update <id> --name mylogstream
 auth0 logs streams update <id> -n mylogstream --type http
 auth0 logs streams update <id> -n mylogstream -t http --http-type application/json --http-format JSONLINES
 auth0 logs streams update <id> -n mydatadog -t datadog --datadog-key 9999999 --datadog-id us
-auth0 logs streams update <id> -n myeventbridge -t eventbridge`,
+auth0 logs streams update <id> -n myeventbridge -t eventbridge
+auth0 logs streams update <id> --filters auth.login.fail,user.fail
+auth0 logs streams update <id> --filters all`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var current *management.LogStream
 
+			filters, err := parseLogStreamFilters(inputs.Filters)
+			if err != nil {
+				return err
+			}
+
 			if len(args) == 0 {
 				err := logsID.Pick(cmd, &inputs.ID, cli.logStreamPickerOptions)
 				if err != nil {
@@ -664,7 +696,14 @@ auth0 logs streams update <id> -n myeventbridge -t eventbridge`,
 
 			// Update a log stream
 			if err := ansi.Waiting(func() error {
-				return cli.api.LogStream.Update(current.GetID(), ls)
+				if err := cli.api.LogStream.Update(current.GetID(), ls); err != nil {
+					return err
+				}
+
+				if logStreamFilters.IsSet(cmd) {
+					return cli.api.LogStreamFilters.Update(current.GetID(), filters)
+				}
+				return nil
 			}); err != nil {
 				return fmt.Errorf("Unable to update log stream: %v", err)
 			}
@@ -688,6 +727,7 @@ auth0 logs streams update <id> -n myeventbridge -t eventbridge`,
 	sumoLogicSource.RegisterStringU(cmd, &inputs.SumoLogicSource, "")
 	datadogApiKey.RegisterStringU(cmd, &inputs.DatadogAPIKey, "")
 	datadogRegion.RegisterStringU(cmd, &inputs.DatadogRegion, "")
+	logStreamFilters.RegisterStringSliceU(cmd, &inputs.Filters, nil)
 
 	return cmd
 }
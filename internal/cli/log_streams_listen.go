@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+)
+
+// logStreamShutdownTimeout is how long the deliveries in flight have to be
+// acknowledged when the receiver stops.
+const logStreamShutdownTimeout = 5 * time.Second
+
+var logStreamListenPort = Flag{
+	Name:      "Port",
+	LongForm:  "port",
+	ShortForm: "p",
+	Help:      "Port to listen on for log stream events.",
+}
+
+func listenLogStreamCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Port              int
+		HttpAuthorization string
+		HttpContentFormat string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Args:  cobra.NoArgs,
+		Short: "Receive the events of an http log stream locally",
+		Long: `Start a local HTTP server that receives the events an http log stream sends, and
+prints them as they arrive.
+
+Expose the server through a tunnel (e.g. ngrok) and point an http log stream to
+the tunnel's URL to develop and test a log ingestion service locally. Requests
+without the expected Authorization header are rejected when --http-auth is set,
+and requests in another content format when --http-format is set.`,
+		Example: `auth0 logs streams listen
+auth0 logs streams listen --port 8080 --http-auth "Bearer my-secret"
+auth0 logs streams listen --http-format jsonlines`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := ""
+			if inputs.HttpContentFormat != "" {
+				format = *apiHTTPContentFormatFor(inputs.HttpContentFormat)
+				switch format {
+				case logStreamFormatJSONLines, logStreamFormatJSONArray, logStreamFormatJSONObject:
+				default:
+					return fmt.Errorf("Unsupported content format '%s', possible values: jsonlines, jsonarray, jsonobject", inputs.HttpContentFormat)
+				}
+			}
+
+			listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(inputs.Port)))
+			if err != nil {
+				return fmt.Errorf("Unable to listen on port %d: %w", inputs.Port, err)
+			}
+
+			logsCh := make(chan []*management.Log)
+			receiver := logStreamReceiver(inputs.HttpAuthorization, format, logsCh, func(err error) {
+				cli.renderer.Warnf("Rejected log stream request: %v", err)
+			})
+
+			// The handlers send to logsCh, so it's only closed once the server
+			// is shut down and none of them is running anymore.
+			handlers := &logStreamHandlers{}
+			server := &http.Server{Handler: handlers.wrap(receiver)}
+
+			shutdown := make(chan struct{})
+			go func() {
+				defer close(shutdown)
+				<-cmd.Context().Done()
+
+				// Let the deliveries in flight be acknowledged, then cancel
+				// the ones that still hang.
+				ctx, cancel := context.WithTimeout(context.Background(), logStreamShutdownTimeout)
+				defer cancel()
+				if err := server.Shutdown(ctx); err != nil {
+					server.Close()
+				}
+			}()
+
+			serveErr := make(chan error, 1)
+			go func() {
+				err := server.Serve(listener)
+				if err != http.ErrServerClosed {
+					server.Close()
+				} else {
+					<-shutdown
+					err = nil
+				}
+				handlers.close()
+				serveErr <- err
+				close(logsCh)
+			}()
+
+			cli.renderer.LogStreamEvents(listener.Addr().String(), logsCh, !cli.debug)
+
+			if err := <-serveErr; err != nil {
+				return fmt.Errorf("Unexpected error while receiving log stream events: %w", err)
+			}
+			return nil
+		},
+	}
+
+	logStreamListenPort.RegisterInt(cmd, &inputs.Port, 8080)
+	httpAuthorization.RegisterString(cmd, &inputs.HttpAuthorization, "")
+	httpContentFormat.RegisterString(cmd, &inputs.HttpContentFormat, "")
+
+	return cmd
+}
+
+// logStreamHandlers tracks the handlers running, so that they can be waited
+// for once the server stops accepting requests.
+type logStreamHandlers struct {
+	mu      sync.Mutex
+	closed  bool
+	running sync.WaitGroup
+}
+
+func (h *logStreamHandlers) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The WaitGroup mustn't be incremented while it's waited for.
+		h.mu.Lock()
+		if h.closed {
+			h.mu.Unlock()
+			http.Error(w, "the receiver is stopping", http.StatusServiceUnavailable)
+			return
+		}
+		h.running.Add(1)
+		h.mu.Unlock()
+
+		defer h.running.Done()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// close rejects the requests that come next, and waits for the handlers
+// running to return.
+func (h *logStreamHandlers) close() {
+	h.mu.Lock()
+	h.closed = true
+	h.mu.Unlock()
+
+	h.running.Wait()
+}
+
+// logStreamReceiver handles the requests of an http log stream, sending the
+// logs they carry to ch. Rejected requests are reported to onReject.
+func logStreamReceiver(authorization, format string, ch chan<- []*management.Log, onReject func(error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reject := func(code int, err error) {
+			onReject(err)
+			http.Error(w, err.Error(), code)
+		}
+
+		if r.Method != http.MethodPost {
+			reject(http.StatusMethodNotAllowed, fmt.Errorf("unexpected method %s", r.Method))
+			return
+		}
+
+		if authorization != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(authorization)) != 1 {
+			reject(http.StatusUnauthorized, fmt.Errorf("invalid Authorization header"))
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			reject(http.StatusBadRequest, err)
+			return
+		}
+
+		logs, err := parseLogStreamPayload(format, body)
+		if err != nil {
+			reject(http.StatusBadRequest, err)
+			return
+		}
+
+		if len(logs) > 0 {
+			select {
+			case ch <- logs:
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
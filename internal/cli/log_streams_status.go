@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	logStreamStatusActive    = "active"
+	logStreamStatusPaused    = "paused"
+	logStreamStatusSuspended = "suspended"
+
+	logStreamFilterCategory = "category"
+	logStreamFilterAll      = "all"
+
+	// logStreamErrorLogs is how many of the latest tenant logs are searched
+	// for the delivery errors of the log streams.
+	logStreamErrorLogs = 100
+)
+
+var (
+	logStreamFilters = Flag{
+		Name:     "Filters",
+		LongForm: "filters",
+		Help: "Comma-separated list of log categories sent to the stream, e.g. auth.login.fail,user.fail. " +
+			"Use 'all' to send every log. Possible values: " + strings.Join(logStreamFilterCategories, ", ") + ".",
+	}
+
+	// logStreamFilterCategories are the categories of logs a log stream can
+	// be limited to.
+	logStreamFilterCategories = []string{
+		"auth.ancillary.fail",
+		"auth.ancillary.success",
+		"auth.login.fail",
+		"auth.login.notification",
+		"auth.login.success",
+		"auth.logout.fail",
+		"auth.logout.success",
+		"auth.signup.fail",
+		"auth.signup.success",
+		"auth.silent_auth.fail",
+		"auth.silent_auth.success",
+		"auth.token_exchange.fail",
+		"auth.token_exchange.success",
+		"management.fail",
+		"management.success",
+		"system.notification",
+		"user.fail",
+		"user.notification",
+		"user.success",
+		"other",
+	}
+)
+
+func statusLogStreamCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		ID string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Show the status of log streams",
+		Long: `Show whether log streams are active, paused or suspended, which logs they receive, and their last delivery error.
+
+Auth0 suspends a log stream when it fails to deliver logs to its sink for too
+long. The management API doesn't expose the delivery errors of a stream, so the
+last delivery error is the latest failure among the last 100 tenant logs that
+mentions the stream. Fix the sink and resume the stream with
+'auth0 logs streams resume'.`,
+		Example: `auth0 logs streams status
+auth0 logs streams status <id>`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				inputs.ID = args[0]
+			}
+
+			var (
+				streams []*management.LogStream
+				logs    []*management.Log
+			)
+			filters := map[string][]auth0.LogStreamFilter{}
+
+			if err := ansi.Waiting(func() error {
+				if inputs.ID != "" {
+					ls, err := cli.api.LogStream.Read(inputs.ID)
+					if err != nil {
+						return err
+					}
+					streams = []*management.LogStream{ls}
+				} else {
+					var err error
+					if streams, err = cli.api.LogStream.List(); err != nil {
+						return err
+					}
+				}
+
+				results := make([][]auth0.LogStreamFilter, len(streams))
+				var g errgroup.Group
+				g.Go(func() error {
+					var err error
+					logs, err = getLatestLogs(cli, logStreamErrorLogs, "")
+					return err
+				})
+				for i, ls := range streams {
+					i, id := i, ls.GetID()
+					g.Go(func() error {
+						var err error
+						results[i], err = cli.api.LogStreamFilters.Read(id)
+						return err
+					})
+				}
+				if err := g.Wait(); err != nil {
+					return err
+				}
+
+				for i, ls := range streams {
+					filters[ls.GetID()] = results[i]
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("Unable to load the status of the log streams: %w", err)
+			}
+
+			cli.renderer.LogStreamStatus(streams, filters, lastLogStreamErrors(streams, logs))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func pauseLogStreamCmd(cli *cli) *cobra.Command {
+	return setLogStreamStatusCmd(cli, "pause", logStreamStatusPaused,
+		"Pause a log stream",
+		"Pause a log stream. Logs aren't delivered to its sink until it's resumed.")
+}
+
+func resumeLogStreamCmd(cli *cli) *cobra.Command {
+	return setLogStreamStatusCmd(cli, "resume", logStreamStatusActive,
+		"Resume a log stream",
+		"Resume a paused or suspended log stream.")
+}
+
+func setLogStreamStatusCmd(cli *cli, use, status, short, long string) *cobra.Command {
+	var inputs struct {
+		ID string
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Args:  cobra.MaximumNArgs(1),
+		Short: short,
+		Long:  long,
+		Example: fmt.Sprintf(`auth0 logs streams %[1]s
+auth0 logs streams %[1]s <id>`, use),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				err := logsID.Pick(cmd, &inputs.ID, cli.logStreamPickerOptions)
+				if err != nil {
+					return err
+				}
+			} else {
+				inputs.ID = args[0]
+			}
+
+			var ls *management.LogStream
+
+			if err := ansi.Waiting(func() error {
+				if err := cli.api.LogStream.Update(inputs.ID, &management.LogStream{Status: &status}); err != nil {
+					return err
+				}
+
+				var err error
+				ls, err = cli.api.LogStream.Read(inputs.ID)
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to %s log stream: %w", use, err)
+			}
+
+			cli.renderer.LogStreamUpdate(ls)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// parseLogStreamFilters turns the categories given with --filters into log
+// stream filters.
+func parseLogStreamFilters(categories []string) ([]auth0.LogStreamFilter, error) {
+	filters := []auth0.LogStreamFilter{}
+
+	for _, c := range categories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+
+		if c == logStreamFilterAll {
+			if len(categories) > 1 {
+				return nil, fmt.Errorf("'%s' can't be combined with other filters", logStreamFilterAll)
+			}
+			return filters, nil
+		}
+
+		if !containsStr(logStreamFilterCategories, c) {
+			return nil, fmt.Errorf("Unknown log category '%s', possible values: %s", c, strings.Join(logStreamFilterCategories, ", "))
+		}
+
+		filters = append(filters, auth0.LogStreamFilter{Type: logStreamFilterCategory, Name: c})
+	}
+
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Name < filters[j].Name })
+	return filters, nil
+}
+
+// lastLogStreamErrors picks, for each log stream, the first failure log
+// mentioning its ID, logs being sorted from the latest.
+func lastLogStreamErrors(streams []*management.LogStream, logs []*management.Log) map[string]*management.Log {
+	lastErrors := map[string]*management.Log{}
+
+	for _, ls := range streams {
+		for _, l := range logs {
+			if strings.HasPrefix(l.GetType(), "f") && strings.Contains(l.GetDescription(), ls.GetID()) {
+				lastErrors[ls.GetID()] = l
+				break
+			}
+		}
+	}
+
+	return lastErrors
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogStreamFilters(t *testing.T) {
+	filters, err := parseLogStreamFilters([]string{"user.fail", " Auth.Login.Fail "})
+	assert.NoError(t, err)
+	assert.Equal(t, []auth0.LogStreamFilter{
+		{Type: "category", Name: "auth.login.fail"},
+		{Type: "category", Name: "user.fail"},
+	}, filters)
+
+	filters, err = parseLogStreamFilters([]string{"all"})
+	assert.NoError(t, err)
+	assert.Empty(t, filters)
+
+	_, err = parseLogStreamFilters([]string{"all", "user.fail"})
+	assert.Error(t, err)
+
+	_, err = parseLogStreamFilters([]string{"auth.login"})
+	assert.Error(t, err)
+}
+
+func TestLastLogStreamErrors(t *testing.T) {
+	streams := []*management.LogStream{
+		{ID: auth0.String("lst_splunk")},
+		{ID: auth0.String("lst_http")},
+	}
+	logs := []*management.Log{
+		{Type: auth0.String("s"), Description: auth0.String("Delivered to lst_splunk")},
+		{Type: auth0.String("fapi"), Description: auth0.String("Failed to deliver logs to lst_splunk: 503")},
+		{Type: auth0.String("fapi"), Description: auth0.String("Failed to deliver logs to lst_splunk: timeout")},
+	}
+
+	lastErrors := lastLogStreamErrors(streams, logs)
+	assert.Equal(t, logs[1], lastErrors["lst_splunk"])
+	assert.NotContains(t, lastErrors, "lst_http")
+}
+
+func TestLogStreamReceiver(t *testing.T) {
+	ch := make(chan []*management.Log, 1)
+	var rejected []error
+	handler := logStreamReceiver("Bearer secret", "", ch, func(err error) { rejected = append(rejected, err) })
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"log_id": "1", "data": {"type": "f"}}`))
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Len(t, rejected, 1)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"log_id": "1", "data": {"type": "f"}}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	res = httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	logs := <-ch
+	assert.Equal(t, "f", logs[0].GetType())
+}
+
+func TestLogStreamHandlers(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	handlers := &logStreamHandlers{}
+	handler := handlers.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+	<-started
+
+	closed := make(chan struct{})
+	go func() {
+		handlers.close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("close returned while a handler was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-closed
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,15 +13,54 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	loginScopes = Flag{
+		Name:     "Scopes",
+		LongForm: "scopes",
+		Help:     "Comma-separated list of the Management API scopes to request, instead of all the ones the CLI uses. Commands needing other scopes fail, or offer to grant them.",
+	}
+
+	loginReadOnly = Flag{
+		Name:     "Read Only",
+		LongForm: "read-only",
+		Help:     "Request only the read scopes, so that the CLI can't change anything on the tenant.",
+	}
+)
+
 func loginCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Scopes   []string
+		ReadOnly bool
+	}
+
 	cmd := &cobra.Command{
 		Use:   "login",
 		Args:  cobra.NoArgs,
 		Short: "Authenticate the Auth0 CLI",
-		Long:  "Sign in to your Auth0 account and authorize the CLI to access the Management API.",
+		Long: `Sign in to your Auth0 account and authorize the CLI to access the Management API.
+
+By default the CLI requests all the scopes its commands use. Use --read-only,
+or --scopes to pick them, for a login with least privileges.`,
+		Example: `auth0 login
+auth0 login --read-only
+auth0 login --scopes read:users,update:users,read:logs`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputs.ReadOnly && len(inputs.Scopes) > 0 {
+				return errors.New("Use either --scopes or --read-only")
+			}
+
+			var scopes []string
+			if inputs.ReadOnly {
+				scopes = auth.ReadOnlyScopes()
+			} else if len(inputs.Scopes) > 0 {
+				var err error
+				if scopes, err = auth.LoginScopes(inputs.Scopes); err != nil {
+					return err
+				}
+			}
+
 			ctx := cmd.Context()
-			_, err := RunLogin(ctx, cli, false)
+			_, err := RunLogin(ctx, cli, false, scopes)
 			if err == nil {
 				cli.tracker.TrackCommandRun(cmd, cli.config.InstallID)
 			}
@@ -28,6 +68,9 @@ func loginCmd(cli *cli) *cobra.Command {
 		},
 	}
 
+	loginScopes.RegisterStringSlice(cmd, &inputs.Scopes, nil)
+	loginReadOnly.RegisterBool(cmd, &inputs.ReadOnly, false)
+
 	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
 		_ = cmd.Flags().MarkHidden("tenant")
 		cmd.Parent().HelpFunc()(cmd, args)
@@ -40,7 +83,8 @@ func loginCmd(cli *cli) *cobra.Command {
 // by showing the login instructions, opening the browser.
 // Use `expired` to run the login from other commands setup:
 // this will only affect the messages.
-func RunLogin(ctx context.Context, cli *cli, expired bool) (tenant, error) {
+// The given scopes are requested, or all the required ones if none are given.
+func RunLogin(ctx context.Context, cli *cli, expired bool, scopes []string) (tenant, error) {
 	if expired {
 		cli.renderer.Warnf("Please sign in to re-authorize the CLI.")
 	} else {
@@ -48,7 +92,7 @@ func RunLogin(ctx context.Context, cli *cli, expired bool) (tenant, error) {
 		fmt.Print("If you don't have an account, please go to https://auth0.com/signup\n\n")
 	}
 
-	state, err := cli.authenticator.Start(ctx)
+	state, err := cli.authenticator.Start(ctx, scopes)
 	if err != nil {
 		return tenant{}, fmt.Errorf("Could not start the authentication process: %w.", err)
 	}
@@ -82,7 +126,7 @@ func RunLogin(ctx context.Context, cli *cli, expired bool) (tenant, error) {
 	cli.renderer.Infof("Tenant: %s\n", res.Domain)
 
 	// store the refresh token
-	secretsStore := &auth.Keyring{}
+	secretsStore := cli.secretStore()
 	err = secretsStore.Set(auth.SecretsNamespace, res.Domain, res.RefreshToken)
 	if err != nil {
 		// log the error but move on
@@ -96,7 +140,7 @@ func RunLogin(ctx context.Context, cli *cli, expired bool) (tenant, error) {
 		ExpiresAt: time.Now().Add(
 			time.Duration(res.ExpiresIn) * time.Second,
 		),
-		Scopes: auth.RequiredScopes(),
+		Scopes: grantedScopes(res.Scopes, scopes),
 	}
 	err = cli.addTenant(t)
 	if err != nil {
@@ -120,3 +164,15 @@ func RunLogin(ctx context.Context, cli *cli, expired bool) (tenant, error) {
 
 	return t, nil
 }
+
+// grantedScopes are the scopes the login was granted, which the token
+// response lists when they differ from the requested ones.
+func grantedScopes(granted, requested []string) []string {
+	if len(granted) > 0 {
+		return granted
+	}
+	if len(requested) > 0 {
+		return requested
+	}
+	return auth.RequiredScopes()
+}
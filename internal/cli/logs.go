@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
 	"github.com/spf13/cobra"
 	"github.com/auth0/go-auth0/management"
 )
@@ -23,6 +27,17 @@ var (
 		ShortForm: "n",
 		Help:      "Number of log entries to show.",
 	}
+
+	logID = Argument{
+		Name: "Log ID",
+		Help: "Id of the log.",
+	}
+
+	logsAround = Flag{
+		Name:     "Around",
+		LongForm: "around",
+		Help:     "Also show the logs of the same user within this window before and after the log, e.g. 5m.",
+	}
 )
 
 func logsCmd(cli *cli) *cobra.Command {
@@ -34,7 +49,9 @@ func logsCmd(cli *cli) *cobra.Command {
 
 	cmd.SetUsageTemplate(resourceUsageTemplate())
 	cmd.AddCommand(listLogsCmd(cli))
+	cmd.AddCommand(showLogCmd(cli))
 	cmd.AddCommand(tailLogsCmd(cli))
+	cmd.AddCommand(statsLogsCmd(cli))
 	cmd.AddCommand(logStreamsCmd(cli))
 
 	return cmd
@@ -77,17 +94,96 @@ auth0 logs ls -n 100`,
 	return cmd
 }
 
+func showLogCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		ID     string
+		Around time.Duration
+	}
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Show a log",
+		Long: `Show the details of a log: the user, client and connection involved, where the
+request came from, the error of failures and the related action executions.
+
+Use --around to also show the logs of the same user, or of the same IP when the
+log has no user, within a window before and after the log.`,
+		Example: `auth0 logs show
+auth0 logs show <log-id>
+auth0 logs show <log-id> --around 5m
+auth0 logs show <log-id> --around 1h --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				inputs.ID = args[0]
+			} else {
+				err := logID.Pick(cmd, &inputs.ID, cli.logPickerOptions)
+				if err != nil {
+					return err
+				}
+			}
+
+			var log *auth0.LogEvent
+			var around []*management.Log
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				if log, err = cli.api.LogEvents.Read(inputs.ID); err != nil {
+					return err
+				}
+
+				if inputs.Around > 0 {
+					around, err = getLogsAround(cli, log.Log, inputs.Around)
+				}
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to load log %s: %w", inputs.ID, err)
+			}
+
+			cli.renderer.LogShow(log, around, inputs.Around)
+			return nil
+		},
+	}
+
+	logsAround.RegisterDuration(cmd, &inputs.Around, 0)
+	return cmd
+}
+
 func tailLogsCmd(cli *cli) *cobra.Command {
 	var inputs struct {
 		Filter string
 		Num    int
+		Alert  string
 	}
 
 	cmd := &cobra.Command{
 		Use:   "tail",
 		Args:  cobra.MaximumNArgs(1),
 		Short: "Tail the tenant logs",
-		Long:  "Tail the tenant logs allowing to filter using Lucene query syntax.",
+		Long: `Tail the tenant logs allowing to filter using Lucene query syntax.
+
+Use --alert with a YAML file of rules to watch the logs as they arrive, from
+when tailing starts. A rule
+fires when more than threshold logs of the given types happen within window,
+counted for each client, ip or user with group_by. Without a
+threshold, any matching log fires the rule. A rule can show a desktop
+notification, POST the alert as JSON to a webhook, or stop tailing with a
+non-zero exit status:
+
+  rules:
+    - name: Failed logins
+      types: [f, fp, fu]
+      threshold: 10
+      window: 1m
+      group_by: client
+      actions:
+        notify: true
+        webhook: https://hooks.example.com/auth0
+    - name: Blocked or leaked
+      types: [limit_wc, pwd_leak]
+      actions:
+        notify: true
+        exit: true`,
 		Example: `auth0 logs tail
 auth0 logs tail --filter "client_id:<client-id>"
 auth0 logs tail --filter "client_name:<client-name>"
@@ -95,9 +191,19 @@ auth0 logs tail --filter "user_id:<user-id>"
 auth0 logs tail --filter "user_name:<user-name>"
 auth0 logs tail --filter "ip:<ip>"
 auth0 logs tail --filter "type:f" # See the full list of type codes at https://auth0.com/docs/logs/log-event-type-codes
-auth0 logs tail -n 100`,
+auth0 logs tail -n 100
+auth0 logs tail --alert alerts.yaml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var rules []*logAlertRule
+			if inputs.Alert != "" {
+				var err error
+				if rules, err = loadLogAlertRules(inputs.Alert); err != nil {
+					return fmt.Errorf("Unable to load the alert rules: %w", err)
+				}
+			}
+
 			lastLogID := ""
+			started := time.Now()
 			list, err := getLatestLogs(cli, inputs.Num, inputs.Filter)
 			if err != nil {
 				return fmt.Errorf("An unexpected error occurred while getting logs: %v", err)
@@ -116,11 +222,20 @@ auth0 logs tail -n 100`,
 
 			logsCh := make(chan []*management.Log)
 
-			go func() {
+			// Set when an alert stops tailing, before logsCh is closed.
+			var exitAlert *logAlert
+
+			go func(first []*management.Log) {
 				// This is pretty important and allows
 				// us to close / terminate the command.
 				defer close(logsCh)
 
+				// Only the logs dated from when tailing started fire
+				// alerts, so that older ones aren't notified again.
+				if exitAlert = cli.fireLogAlerts(rules, logsDatedFrom(first, started)); exitAlert != nil {
+					return
+				}
+
 				for {
 					queryParams := []management.RequestOption{
 						management.Query(fmt.Sprintf("log_id:[%s TO *]", lastLogID)),
@@ -140,8 +255,13 @@ auth0 logs tail -n 100`,
 					}
 
 					if len(list) > 0 {
-						logsCh <- dedupLogs(list, set)
+						logs := dedupLogs(list, set)
+						logsCh <- logs
 						lastLogID = list[len(list)-1].GetLogID()
+
+						if exitAlert = cli.fireLogAlerts(rules, logsDatedFrom(logs, started)); exitAlert != nil {
+							return
+						}
 					}
 
 					if len(list) < 90 {
@@ -150,15 +270,20 @@ auth0 logs tail -n 100`,
 					}
 				}
 
-			}()
+			}(list)
 
 			cli.renderer.LogList(list, logsCh, !cli.debug)
+
+			if exitAlert != nil {
+				return fmt.Errorf("Alert %s", exitAlert.Message)
+			}
 			return nil
 		},
 	}
 
 	logsFilter.RegisterString(cmd, &inputs.Filter, "")
 	logsNum.RegisterInt(cmd, &inputs.Num, 100)
+	logsAlert.RegisterString(cmd, &inputs.Alert, "")
 	return cmd
 }
 
@@ -184,6 +309,58 @@ func getLatestLogs(cli *cli, n int, filter string) ([]*management.Log, error) {
 	return cli.api.Log.List(queryParams...)
 }
 
+// getLogsAround fetches the logs of the same user as the given log, or of the
+// same IP when it has no user, dated within window of it.
+func getLogsAround(cli *cli, l *management.Log, window time.Duration) ([]*management.Log, error) {
+	var who string
+	switch {
+	case l.GetUserID() != "":
+		who = fmt.Sprintf("user_id:%q", l.GetUserID())
+	case l.GetIP() != "":
+		who = fmt.Sprintf("ip:%q", l.GetIP())
+	default:
+		return nil, fmt.Errorf("the log has no user or IP to look for")
+	}
+
+	query := fmt.Sprintf("%s AND date:[%s TO %s]", who,
+		l.GetDate().Add(-window).UTC().Format(time.RFC3339),
+		l.GetDate().Add(window).UTC().Format(time.RFC3339))
+
+	list, err := cli.api.Log.List(
+		management.Query(query),
+		management.Parameter("sort", "date:1"),
+		management.Parameter("page", "0"),
+		management.Parameter("per_page", fmt.Sprintf("%d", logsPerPage)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if list == nil {
+		list = []*management.Log{}
+	}
+	return list, nil
+}
+
+func (c *cli) logPickerOptions() (pickerOptions, error) {
+	list, err := getLatestLogs(c, 50, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var opts pickerOptions
+	for _, l := range list {
+		label := fmt.Sprintf("%s %s %s", l.GetDate().Format(time.Stamp), l.TypeName(), l.GetDescription())
+		opts = append(opts, pickerOption{value: l.GetLogID(), label: strings.TrimSpace(label)})
+	}
+
+	if len(opts) == 0 {
+		return nil, errors.New("There are currently no logs.")
+	}
+
+	return opts, nil
+}
+
 func dedupLogs(list []*management.Log, set map[string]struct{}) []*management.Log {
 	res := make([]*management.Log, 0, len(list))
 
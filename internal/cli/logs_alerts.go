@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	logAlertGroupByClient = "client"
+	logAlertGroupByIP     = "ip"
+	logAlertGroupByUser   = "user"
+
+	logAlertWebhookTimeout = 10 * time.Second
+)
+
+var logsAlert = Flag{
+	Name:     "Alert Rules",
+	LongForm: "alert",
+	Help:     "Path to a YAML file of alert rules evaluated on the logs as they arrive.",
+}
+
+// logAlertRules is the content of the file given with --alert.
+type logAlertRules struct {
+	Rules []*logAlertRule `yaml:"rules"`
+}
+
+// logAlertRule fires its actions when more than Threshold logs of the given
+// types happen within Window. Logs are counted separately for each value of
+// GroupBy, e.g. for each client.
+type logAlertRule struct {
+	Name      string          `yaml:"name"`
+	Types     []string        `yaml:"types"`
+	Client    string          `yaml:"client"`
+	Threshold int             `yaml:"threshold"`
+	Window    time.Duration   `yaml:"window"`
+	GroupBy   string          `yaml:"group_by"`
+	Actions   logAlertActions `yaml:"actions"`
+
+	matches map[string][]*management.Log
+}
+
+type logAlertActions struct {
+	Notify  bool   `yaml:"notify"`
+	Webhook string `yaml:"webhook"`
+	Exit    bool   `yaml:"exit"`
+}
+
+// logAlert is what a rule reports when it fires. It's the payload of the
+// webhook action.
+type logAlert struct {
+	Rule    string            `json:"rule"`
+	Group   string            `json:"group,omitempty"`
+	Count   int               `json:"count"`
+	Message string            `json:"message"`
+	Logs    []*management.Log `json:"logs"`
+
+	actions logAlertActions
+}
+
+func loadLogAlertRules(filename string) ([]*logAlertRule, error) {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseLogAlertRules(buf)
+}
+
+func parseLogAlertRules(buf []byte) ([]*logAlertRule, error) {
+	var rules logAlertRules
+	if err := yaml.UnmarshalStrict(buf, &rules); err != nil {
+		return nil, err
+	}
+
+	if len(rules.Rules) == 0 {
+		return nil, fmt.Errorf("no rules defined")
+	}
+
+	for i, r := range rules.Rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("Rule #%d", i+1)
+		}
+
+		if len(r.Types) == 0 && r.Client == "" {
+			return nil, fmt.Errorf("%s: at least one of types or client is required", r.Name)
+		}
+
+		if r.Threshold < 0 {
+			return nil, fmt.Errorf("%s: threshold can't be negative", r.Name)
+		}
+		if r.Threshold > 0 && r.Window <= 0 {
+			return nil, fmt.Errorf("%s: a window is required with a threshold, e.g. 1m", r.Name)
+		}
+
+		switch r.GroupBy {
+		case "", logAlertGroupByClient, logAlertGroupByIP, logAlertGroupByUser:
+		default:
+			return nil, fmt.Errorf("%s: unknown group_by '%s', possible values: client, ip, user", r.Name, r.GroupBy)
+		}
+
+		if !r.Actions.Notify && r.Actions.Webhook == "" && !r.Actions.Exit {
+			return nil, fmt.Errorf("%s: at least one action is required: notify, webhook or exit", r.Name)
+		}
+
+		r.matches = map[string][]*management.Log{}
+	}
+
+	return rules.Rules, nil
+}
+
+// evaluate counts the log if it matches the rule, and returns an alert when
+// the rule fires. The count starts over once the rule has fired.
+func (r *logAlertRule) evaluate(l *management.Log) *logAlert {
+	if !r.match(l) {
+		return nil
+	}
+
+	group := r.group(l)
+	matches := append(r.matches[group], l)
+
+	if r.Window > 0 {
+		since := l.GetDate().Add(-r.Window)
+		for len(matches) > 0 && matches[0].GetDate().Before(since) {
+			matches = matches[1:]
+		}
+	}
+
+	if len(matches) <= r.Threshold {
+		r.matches[group] = matches
+		return nil
+	}
+
+	delete(r.matches, group)
+
+	alert := &logAlert{
+		Rule:    r.Name,
+		Group:   group,
+		Count:   len(matches),
+		Logs:    matches,
+		actions: r.Actions,
+	}
+
+	switch {
+	case r.Threshold == 0:
+		alert.Message = fmt.Sprintf("%s: %s", r.Name, logAlertDescription(l))
+	case group != "":
+		alert.Message = fmt.Sprintf("%s: %d logs in %s for %s", r.Name, alert.Count, r.Window, group)
+	default:
+		alert.Message = fmt.Sprintf("%s: %d logs in %s", r.Name, alert.Count, r.Window)
+	}
+
+	return alert
+}
+
+func (r *logAlertRule) match(l *management.Log) bool {
+	if len(r.Types) > 0 && !containsStr(r.Types, l.GetType()) {
+		return false
+	}
+	if r.Client != "" && r.Client != l.GetClientID() && r.Client != l.GetClientName() {
+		return false
+	}
+	return true
+}
+
+func (r *logAlertRule) group(l *management.Log) string {
+	switch r.GroupBy {
+	case logAlertGroupByClient:
+		if l.GetClientName() != "" {
+			return l.GetClientName()
+		}
+		return l.GetClientID()
+	case logAlertGroupByIP:
+		return l.GetIP()
+	case logAlertGroupByUser:
+		return l.GetUserID()
+	}
+	return ""
+}
+
+func logAlertDescription(l *management.Log) string {
+	desc := l.GetType()
+	if l.GetDescription() != "" {
+		desc = fmt.Sprintf("%s (%s)", desc, l.GetDescription())
+	}
+	if l.GetClientName() != "" {
+		desc = fmt.Sprintf("%s for %s", desc, l.GetClientName())
+	}
+	return desc
+}
+
+// logsDatedFrom returns the logs dated at or after t.
+func logsDatedFrom(logs []*management.Log, t time.Time) []*management.Log {
+	var res []*management.Log
+	for _, l := range logs {
+		if !l.GetDate().Before(t) {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// fireLogAlerts evaluates the rules on the logs and runs the actions of the
+// alerts that fire. It returns the first alert with the exit action, if any.
+func (c *cli) fireLogAlerts(rules []*logAlertRule, logs []*management.Log) *logAlert {
+	for _, l := range logs {
+		for _, r := range rules {
+			alert := r.evaluate(l)
+			if alert == nil {
+				continue
+			}
+
+			c.renderer.Warnf("Alert %s", alert.Message)
+
+			if alert.actions.Notify {
+				if err := desktopNotification("Auth0 alert", alert.Message); err != nil {
+					c.renderer.Warnf("Unable to show a desktop notification: %v", err)
+				}
+			}
+
+			if alert.actions.Webhook != "" {
+				if err := postLogAlert(c.httpTransport(), alert.actions.Webhook, alert); err != nil {
+					c.renderer.Warnf("Unable to send the alert to the webhook: %v", err)
+				}
+			}
+
+			if alert.actions.Exit {
+				return alert
+			}
+		}
+	}
+
+	return nil
+}
+
+func postLogAlert(rt http.RoundTripper, url string, alert *logAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Transport: rt, Timeout: logAlertWebhookTimeout}
+	res, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// desktopNotification shows a notification with the tools each platform
+// ships with.
+func desktopNotification(title, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		cmd = exec.Command("osascript", "-e",
+			fmt.Sprintf(`display notification "%s" with title "%s"`, quote.Replace(message), quote.Replace(title)))
+	case "windows":
+		quote := strings.NewReplacer(`'`, `''`)
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", fmt.Sprintf(
+			`Add-Type -AssemblyName System.Windows.Forms; `+
+				`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+				`$n.Icon = [System.Drawing.SystemIcons]::Warning; $n.Visible = $true; `+
+				`$n.ShowBalloonTip(10000, '%s', '%s', 'Warning'); Start-Sleep -Seconds 10; $n.Dispose()`,
+			quote.Replace(title), quote.Replace(message)))
+		return cmd.Start()
+	default:
+		cmd = exec.Command("notify-send", "--urgency=critical", title, message)
+	}
+
+	return cmd.Run()
+}
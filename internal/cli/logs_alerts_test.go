@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogAlertRules(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		rules, err := parseLogAlertRules([]byte(`
+rules:
+  - name: Failed logins
+    types: [f, fp]
+    threshold: 10
+    window: 1m
+    group_by: client
+    actions:
+      notify: true
+      webhook: https://hooks.example.com
+  - types: [limit_wc, pwd_leak]
+    actions:
+      exit: true
+`))
+
+		assert.NoError(t, err)
+		assert.Len(t, rules, 2)
+		assert.Equal(t, "Failed logins", rules[0].Name)
+		assert.Equal(t, time.Minute, rules[0].Window)
+		assert.Equal(t, 10, rules[0].Threshold)
+		assert.Equal(t, "https://hooks.example.com", rules[0].Actions.Webhook)
+		assert.Equal(t, "Rule #2", rules[1].Name)
+		assert.True(t, rules[1].Actions.Exit)
+	})
+
+	tests := []struct {
+		name string
+		yaml string
+		err  string
+	}{
+		{"no rules", "rules: []", "no rules defined"},
+		{"no match", "rules: [{actions: {exit: true}}]", "Rule #1: at least one of types or client is required"},
+		{"no window", "rules: [{types: [f], threshold: 2, actions: {exit: true}}]", "Rule #1: a window is required with a threshold, e.g. 1m"},
+		{"bad group", "rules: [{types: [f], group_by: country, actions: {exit: true}}]", "Rule #1: unknown group_by 'country', possible values: client, ip, user"},
+		{"no actions", "rules: [{types: [f]}]", "Rule #1: at least one action is required: notify, webhook or exit"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseLogAlertRules([]byte(test.yaml))
+			assert.EqualError(t, err, test.err)
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := parseLogAlertRules([]byte("rules: [{types: [f], treshold: 2, actions: {exit: true}}]"))
+		assert.Error(t, err)
+	})
+}
+
+func TestLogAlertRuleEvaluate(t *testing.T) {
+	start := time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	log := func(typ, client string, at time.Duration) *management.Log {
+		return &management.Log{
+			Type:       auth0.String(typ),
+			ClientName: auth0.String(client),
+			Date:       auth0.Time(start.Add(at)),
+		}
+	}
+
+	t.Run("threshold within a window for each group", func(t *testing.T) {
+		rules, err := parseLogAlertRules([]byte(`
+rules:
+  - name: Failed logins
+    types: [f]
+    threshold: 2
+    window: 1m
+    group_by: client
+    actions: {notify: true}
+`))
+		assert.NoError(t, err)
+		r := rules[0]
+
+		assert.Nil(t, r.evaluate(log("f", "app-a", 0)))
+		assert.Nil(t, r.evaluate(log("f", "app-a", 10*time.Second)))
+		assert.Nil(t, r.evaluate(log("s", "app-a", 15*time.Second)))
+		assert.Nil(t, r.evaluate(log("f", "app-b", 20*time.Second)))
+		// The first failure is out of the window by now.
+		assert.Nil(t, r.evaluate(log("f", "app-a", 65*time.Second)))
+
+		alert := r.evaluate(log("f", "app-a", 66*time.Second))
+		assert.NotNil(t, alert)
+		assert.Equal(t, "app-a", alert.Group)
+		assert.Equal(t, 3, alert.Count)
+		assert.Equal(t, "Failed logins: 3 logs in 1m0s for app-a", alert.Message)
+
+		// The count starts over once the rule has fired.
+		assert.Nil(t, r.evaluate(log("f", "app-a", 67*time.Second)))
+	})
+
+	t.Run("any matching log", func(t *testing.T) {
+		rules, err := parseLogAlertRules([]byte(`
+rules:
+  - name: Leaked password
+    types: [pwd_leak]
+    actions: {exit: true}
+`))
+		assert.NoError(t, err)
+
+		alert := rules[0].evaluate(log("pwd_leak", "app-a", 0))
+		assert.NotNil(t, alert)
+		assert.Equal(t, "Leaked password: pwd_leak for app-a", alert.Message)
+		assert.True(t, alert.actions.Exit)
+	})
+}
+
+func TestLogsDatedFrom(t *testing.T) {
+	now := time.Now()
+	logs := []*management.Log{
+		{LogID: auth0.String("1"), Date: auth0.Time(now.Add(-time.Hour))},
+		{LogID: auth0.String("2"), Date: auth0.Time(now)},
+		{LogID: auth0.String("3"), Date: auth0.Time(now.Add(time.Second))},
+	}
+
+	assert.Equal(t, logs[1:], logsDatedFrom(logs, now))
+	assert.Empty(t, logsDatedFrom(logs[:1], now))
+}
+
+func TestPostLogAlert(t *testing.T) {
+	var got logAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	err := postLogAlert(nil, server.URL, &logAlert{Rule: "Failed logins", Count: 3, Message: "Failed logins: 3 logs"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Failed logins", got.Rule)
+	assert.Equal(t, 3, got.Count)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	err = postLogAlert(nil, failing.URL, &logAlert{Rule: "Failed logins"})
+	assert.EqualError(t, err, "unexpected status 500 Internal Server Error")
+}
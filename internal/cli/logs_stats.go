@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// logsMaxPages is how far the page based pagination of logs goes.
+	// https://auth0.com/docs/logs/retrieve-log-events-using-mgmt-api#limitations
+	logsMaxPages   = 10
+	logsPerPage    = 100
+	logsStatsTicks = 24
+)
+
+var (
+	logsSince = Flag{
+		Name:     "Since",
+		LongForm: "since",
+		Help:     "How far back to aggregate logs, e.g. 1h or 24h.",
+	}
+
+	logsArchive = Flag{
+		Name:     "Archive",
+		LongForm: "file",
+		Help:     "Path to a local archive of logs to aggregate instead of the tenant logs, as a JSON array or JSON lines (e.g. saved with 'auth0 logs list --format json').",
+	}
+
+	logsTop = Flag{
+		Name:     "Top",
+		LongForm: "top",
+		Help:     "Number of entries to show in each table.",
+	}
+)
+
+func statsLogsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Since  time.Duration
+		File   string
+		Filter string
+		Top    int
+	}
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Args:  cobra.NoArgs,
+		Short: "Show statistics of the tenant logs",
+		Long: `Show statistics of the tenant logs: the most frequent event types, clients,
+connections and IPs, and how failures spread over time.
+
+The management API only returns the latest 1000 logs of a search; use --file
+with an archive of logs to aggregate more. An archive is aggregated from its
+first to its last log, unless --since is given.`,
+		Example: `auth0 logs stats
+auth0 logs stats --since 1h
+auth0 logs stats --since 24h --filter "client_name:<client-name>" --top 5
+auth0 logs stats --file logs.json
+auth0 logs stats --since 168h --file logs.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			until := time.Now()
+			since := until.Add(-inputs.Since)
+
+			var logs []*management.Log
+
+			if inputs.File != "" {
+				// The search syntax is only understood by the tenant.
+				if inputs.Filter != "" {
+					return errors.New("--filter can't be used with --file, filter the archive before aggregating it instead")
+				}
+
+				buf, err := ioutil.ReadFile(inputs.File)
+				if err != nil {
+					return fmt.Errorf("Unable to read the logs archive: %w", err)
+				}
+
+				if logs, err = parseLogStreamPayload("", buf); err != nil {
+					return fmt.Errorf("Unable to parse the logs archive: %w", err)
+				}
+
+				// An archive is aggregated over the time it spans,
+				// unless told otherwise.
+				if !cmd.Flags().Changed(logsSince.LongForm) && len(logs) > 0 {
+					since, until = logsDateRange(logs)
+				}
+			} else {
+				var truncated bool
+				if err := ansi.Waiting(func() error {
+					var err error
+					logs, truncated, err = getLogsSince(cli, since, inputs.Filter)
+					return err
+				}); err != nil {
+					return fmt.Errorf("An unexpected error occurred while getting logs: %v", err)
+				}
+
+				if truncated {
+					cli.renderer.Warnf("Only the latest %d logs were aggregated, use a shorter --since or --file with an archive of logs.", logsMaxPages*logsPerPage)
+				}
+			}
+
+			cli.renderer.LogStats(display.NewLogStats(logs, since, until, inputs.Top, logsStatsTicks))
+			return nil
+		},
+	}
+
+	logsSince.RegisterDuration(cmd, &inputs.Since, 24*time.Hour)
+	logsArchive.RegisterString(cmd, &inputs.File, "")
+	logsFilter.RegisterString(cmd, &inputs.Filter, "")
+	logsTop.RegisterInt(cmd, &inputs.Top, 10)
+	return cmd
+}
+
+// getLogsSince fetches the logs dated after since, as far as the pagination
+// of logs allows. truncated is true when there are older logs that couldn't
+// be fetched.
+func getLogsSince(cli *cli, since time.Time, filter string) (logs []*management.Log, truncated bool, err error) {
+	query := fmt.Sprintf("date:[%s TO *]", since.UTC().Format(time.RFC3339))
+	if filter != "" {
+		query = fmt.Sprintf("(%s) AND %s", filter, query)
+	}
+
+	for page := 0; page < logsMaxPages; page++ {
+		list, err := cli.api.Log.List(
+			management.Query(query),
+			management.Parameter("sort", "date:-1"),
+			management.Parameter("page", fmt.Sprintf("%d", page)),
+			management.Parameter("per_page", fmt.Sprintf("%d", logsPerPage)),
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		logs = append(logs, list...)
+		if len(list) < logsPerPage {
+			return logs, false, nil
+		}
+	}
+
+	return logs, true, nil
+}
+
+// logsDateRange returns the dates of the first and the last logs.
+func logsDateRange(logs []*management.Log) (first, last time.Time) {
+	for i, l := range logs {
+		d := l.GetDate()
+		if i == 0 || d.Before(first) {
+			first = d
+		}
+		if i == 0 || d.After(last) {
+			last = d
+		}
+	}
+	return first, last
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLogsSince(t *testing.T) {
+	page := func(n int) []*management.Log {
+		logs := make([]*management.Log, n)
+		for i := range logs {
+			logs[i] = &management.Log{LogID: auth0.String("log-id")}
+		}
+		return logs
+	}
+
+	since := time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("stops at the last page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logAPI := auth0.NewMockLogAPI(ctrl)
+		gomock.InOrder(
+			logAPI.EXPECT().List(gomock.Any()).Return(page(logsPerPage), nil),
+			logAPI.EXPECT().List(gomock.Any()).Return(page(3), nil),
+		)
+
+		cli := &cli{api: &auth0.API{Log: logAPI}}
+		logs, truncated, err := getLogsSince(cli, since, "type:f")
+
+		assert.NoError(t, err)
+		assert.False(t, truncated)
+		assert.Len(t, logs, logsPerPage+3)
+	})
+
+	t.Run("truncates after the last page allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logAPI := auth0.NewMockLogAPI(ctrl)
+		logAPI.EXPECT().List(gomock.Any()).Return(page(logsPerPage), nil).Times(logsMaxPages)
+
+		cli := &cli{api: &auth0.API{Log: logAPI}}
+		logs, truncated, err := getLogsSince(cli, since, "")
+
+		assert.NoError(t, err)
+		assert.True(t, truncated)
+		assert.Len(t, logs, logsMaxPages*logsPerPage)
+	})
+}
+
+func TestLogsDateRange(t *testing.T) {
+	day := time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC)
+	logs := []*management.Log{
+		{Date: auth0.Time(day.Add(time.Hour))},
+		{Date: auth0.Time(day)},
+		{Date: auth0.Time(day.Add(48 * time.Hour))},
+		{Date: auth0.Time(day.Add(2 * time.Hour))},
+	}
+
+	first, last := logsDateRange(logs)
+	assert.Equal(t, day, first)
+	assert.Equal(t, day.Add(48*time.Hour), last)
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLogsAround(t *testing.T) {
+	date := time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("without results", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logAPI := auth0.NewMockLogAPI(ctrl)
+		logAPI.EXPECT().List(gomock.Any()).Return(nil, nil)
+
+		cli := &cli{api: &auth0.API{Log: logAPI}}
+		logs, err := getLogsAround(cli, &management.Log{UserID: auth0.String("auth0|123"), Date: &date}, 5*time.Minute)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, logs)
+		assert.Len(t, logs, 0)
+	})
+
+	t.Run("without user or IP", func(t *testing.T) {
+		cli := &cli{api: &auth0.API{}}
+		_, err := getLogsAround(cli, &management.Log{Date: &date}, 5*time.Minute)
+
+		assert.EqualError(t, err, "the log has no user or IP to look for")
+	})
+}
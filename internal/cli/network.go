@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/spf13/cobra"
+)
+
+// networkFlags are the persistent flags saved by config network set.
+var networkFlags = []string{"ca-file", "client-cert", "client-key"}
+
+func networkCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Manage how the CLI connects to Auth0",
+		Long: `Manage how the CLI connects to Auth0, e.g. on corporate networks.
+
+Requests go through the proxy set by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+env vars. The CA bundle and the client certificate for mTLS are set by the
+--ca-file, --client-cert and --client-key flags, the AUTH0_CA_FILE,
+AUTH0_CLIENT_CERT and AUTH0_CLIENT_KEY env vars, or the config, in that order.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showNetworkCmd(cli))
+	cmd.AddCommand(setNetworkCmd(cli))
+	cmd.AddCommand(clearNetworkCmd(cli))
+	return cmd
+}
+
+func showNetworkCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show",
+		Args:    cobra.NoArgs,
+		Short:   "Show how the CLI connects to Auth0",
+		Long:    "Show the proxy, the CA bundle and the client certificate the CLI connects to Auth0 with.",
+		Example: "auth0 config network show",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proxy := "none"
+			req := &http.Request{URL: &url.URL{Scheme: "https", Host: "auth0.auth0.com"}}
+			if u, err := http.ProxyFromEnvironment(req); err == nil && u != nil {
+				proxy = u.Redacted()
+			}
+
+			opts := cli.networkOptions()
+			cli.renderer.Infof("Proxy: %s", proxy)
+			cli.renderer.Infof("CA file: %s", valueOrNone(opts.CAFile))
+			cli.renderer.Infof("Client certificate: %s", valueOrNone(opts.CertFile))
+			cli.renderer.Infof("Client key: %s", valueOrNone(opts.KeyFile))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func setNetworkCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Args:  cobra.NoArgs,
+		Short: "Save the CA bundle and the client certificate to the config",
+		Long: `Save the CA bundle and the client certificate given by the --ca-file,
+--client-cert and --client-key flags to the config, so that they're used by
+every command.`,
+		Example: `auth0 config network set --ca-file corporate-ca.pem
+auth0 config network set --client-cert cert.pem --client-key key.pem`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changed := false
+			for _, name := range networkFlags {
+				changed = changed || cmd.Flags().Changed(name)
+			}
+			if !changed {
+				return errors.New("Use --ca-file, --client-cert or --client-key to set how to connect")
+			}
+
+			// The files were loaded successfully when the command started,
+			// so they're only made absolute to be found from anywhere.
+			for name, v := range map[string]*string{
+				"ca-file":     &cli.config.CAFile,
+				"client-cert": &cli.config.ClientCert,
+				"client-key":  &cli.config.ClientKey,
+			} {
+				if !cmd.Flags().Changed(name) {
+					continue
+				}
+				path, err := filepath.Abs(cmd.Flags().Lookup(name).Value.String())
+				if err != nil {
+					return err
+				}
+				*v = path
+			}
+
+			if (cli.config.ClientCert == "") != (cli.config.ClientKey == "") {
+				return errors.New("Both a client certificate and its key are required")
+			}
+
+			if err := cli.persistConfig(); err != nil {
+				return fmt.Errorf("Unexpected error persisting config: %w", err)
+			}
+
+			cli.renderer.Infof("Saved how to connect to Auth0 to the config")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func clearNetworkCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "clear",
+		Args:    cobra.NoArgs,
+		Short:   "Remove the CA bundle and the client certificate from the config",
+		Long:    "Remove the CA bundle and the client certificate from the config.",
+		Example: "auth0 config network clear",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli.config.CAFile = ""
+			cli.config.ClientCert = ""
+			cli.config.ClientKey = ""
+
+			if err := cli.persistConfig(); err != nil {
+				return fmt.Errorf("Unexpected error persisting config: %w", err)
+			}
+
+			cli.renderer.Infof("Removed how to connect to Auth0 from the config")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func valueOrNone(v string) string {
+	if v == "" {
+		return ansi.Faint("none")
+	}
+	return v
+}
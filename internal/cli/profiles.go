@@ -0,0 +1,402 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/auth0-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileName = Argument{
+		Name: "Profile",
+		Help: "Name of the profile",
+	}
+
+	profileApp = Flag{
+		Name:      "Application",
+		LongForm:  "app",
+		ShortForm: "a",
+		Help:      "Client ID of the default application of the profile.",
+	}
+
+	profileAudience = Flag{
+		Name:     "Audience",
+		LongForm: "audience",
+		Help:     "Identifier of the default API of the profile, used e.g. by 'auth0 test token'.",
+	}
+
+	errNoProfiles = errors.New("There are no profiles; run 'auth0 profiles create' to create one")
+)
+
+// profile bundles the tenant, defaults and output preferences of a working
+// context, e.g. a customer, so that switching between them is one command.
+type profile struct {
+	Tenant   string `json:"tenant,omitempty"`
+	AppID    string `json:"app_id,omitempty"`
+	Audience string `json:"audience,omitempty"`
+	Format   string `json:"format,omitempty"`
+	NoInput  bool   `json:"no_input,omitempty"`
+}
+
+func profilesCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage profiles",
+		Long: `Manage profiles, which bundle a tenant, a default application and API, and
+output preferences.
+
+The active profile is set with 'auth0 profiles use', and can be overridden
+with the --profile flag or the AUTH0_PROFILE env var. Flags passed explicitly
+always take precedence over the active profile.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(listProfilesCmd(cli))
+	cmd.AddCommand(createProfileCmd(cli))
+	cmd.AddCommand(useProfileCmd(cli))
+	cmd.AddCommand(deleteProfileCmd(cli))
+	return cmd
+}
+
+func listProfilesCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Short:   "List your profiles",
+		Long:    "List your profiles.",
+		Example: "auth0 profiles list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = cli.init()
+
+			active := cli.activeProfile()
+
+			var profiles []display.Profile
+			for _, name := range cli.profileNames() {
+				p := cli.config.Profiles[name]
+				profiles = append(profiles, display.Profile{
+					Name:     name,
+					Tenant:   p.Tenant,
+					AppID:    p.AppID,
+					Audience: p.Audience,
+					Format:   p.Format,
+					NoInput:  p.NoInput,
+					Active:   name == active,
+				})
+			}
+
+			cli.renderer.ProfileList(profiles)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func createProfileCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Name     string
+		AppID    string
+		Audience string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Create a profile",
+		Long: `Create a profile, or replace an existing one, from the global flags given:
+the tenant with --tenant, the output format with --format and whether to
+prompt with --no-input.`,
+		Example: `auth0 profiles create acme --tenant acme.auth0.com
+auth0 profiles create acme-ci --tenant acme.auth0.com --format json --no-input
+auth0 profiles create travel0 --tenant travel0.us.auth0.com --app <client-id> --audience https://api.travel0.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				if err := profileName.Ask(cmd, &inputs.Name); err != nil {
+					return err
+				}
+			} else {
+				inputs.Name = args[0]
+			}
+
+			if err := cli.init(); err != nil {
+				return err
+			}
+
+			// Only the flags given are bundled, not the defaults the
+			// active profile might have set.
+			p := profile{
+				AppID:    inputs.AppID,
+				Audience: inputs.Audience,
+			}
+			if cmd.Flags().Changed("format") {
+				p.Format = cli.format
+			}
+			if cmd.Flags().Changed("no-input") {
+				p.NoInput = cli.noInput
+			}
+
+			if cmd.Flags().Changed("tenant") {
+				p.Tenant = cli.tenant
+			} else {
+				if err := tenantDomain.Pick(cmd, &p.Tenant, cli.tenantPickerOptions); err != nil {
+					return err
+				}
+			}
+
+			if _, ok := cli.config.Tenants[p.Tenant]; !ok {
+				return fmt.Errorf("Unable to find tenant: %s; run 'auth0 tenants use' to see your configured tenants or run 'auth0 login' to configure a new tenant", p.Tenant)
+			}
+
+			if cli.config.Profiles == nil {
+				cli.config.Profiles = map[string]profile{}
+			}
+			cli.config.Profiles[inputs.Name] = p
+
+			if err := cli.persistConfig(); err != nil {
+				return fmt.Errorf("Unexpected error persisting config: %w", err)
+			}
+
+			cli.renderer.Infof("Profile %s created, run 'auth0 profiles use %s' to activate it", inputs.Name, inputs.Name)
+			return nil
+		},
+	}
+
+	profileApp.RegisterString(cmd, &inputs.AppID, "")
+	profileAudience.RegisterString(cmd, &inputs.Audience, "")
+
+	return cmd
+}
+
+func useProfileCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Name string
+		None bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "use",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Set the active profile",
+		Long:  "Set the active profile, or deactivate profiles with --none.",
+		Example: `auth0 profiles use <profile>
+auth0 profiles use --none`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.init(); err != nil {
+				return err
+			}
+
+			switch {
+			case inputs.None:
+				inputs.Name = ""
+			case len(args) > 0:
+				inputs.Name = args[0]
+			default:
+				if err := profileName.Pick(cmd, &inputs.Name, cli.profilePickerOptions); err != nil {
+					return err
+				}
+			}
+
+			if _, ok := cli.config.Profiles[inputs.Name]; inputs.Name != "" && !ok {
+				return fmt.Errorf("Unable to find profile: %s; run 'auth0 profiles list' to see your profiles", inputs.Name)
+			}
+
+			cli.config.DefaultProfile = inputs.Name
+			if err := cli.persistConfig(); err != nil {
+				return fmt.Errorf("An error occurred while setting the active profile: %w", err)
+			}
+
+			if inputs.Name == "" {
+				cli.renderer.Infof("Profiles deactivated")
+				return nil
+			}
+
+			cli.renderer.Infof("Active profile switched to: %s", inputs.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&inputs.None, "none", false, "Deactivate profiles.")
+
+	return cmd
+}
+
+func deleteProfileCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Name string
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"rm"},
+		Args:    cobra.MaximumNArgs(1),
+		Short:   "Delete a profile",
+		Long:    "Delete a profile.",
+		Example: "auth0 profiles delete <profile>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.init(); err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				if err := profileName.Pick(cmd, &inputs.Name, cli.profilePickerOptions); err != nil {
+					return err
+				}
+			} else {
+				inputs.Name = args[0]
+			}
+
+			if _, ok := cli.config.Profiles[inputs.Name]; !ok {
+				return fmt.Errorf("Unable to find profile: %s; run 'auth0 profiles list' to see your profiles", inputs.Name)
+			}
+
+			if !cli.force && canPrompt(cmd) {
+				if confirmed := prompt.Confirm(fmt.Sprintf("Are you sure you want to delete the profile %s?", inputs.Name)); !confirmed {
+					return nil
+				}
+			}
+
+			delete(cli.config.Profiles, inputs.Name)
+			if cli.config.DefaultProfile == inputs.Name {
+				cli.config.DefaultProfile = ""
+			}
+
+			if err := cli.persistConfig(); err != nil {
+				return fmt.Errorf("Unexpected error persisting config: %w", err)
+			}
+
+			cli.renderer.Infof("Profile %s deleted", inputs.Name)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// activeProfile is the name of the profile in use, picked from the --profile
+// flag, the AUTH0_PROFILE env var or the profile set with 'auth0 profiles use'.
+func (c *cli) activeProfile() string {
+	if c.profile != "" {
+		return c.profile
+	}
+	if p := os.Getenv("AUTH0_PROFILE"); p != "" {
+		return p
+	}
+	return c.config.DefaultProfile
+}
+
+// applyProfile sets the defaults of the active profile for all the flags that
+// weren't explicitly set.
+func (c *cli) applyProfile(cmd *cobra.Command) error {
+	// config init picks the config file to write, so no config must be
+	// read before it runs.
+	if cmd.Use == "init" && cmd.Parent() != nil && cmd.Parent().Use == "config" {
+		return nil
+	}
+
+	// init fails when not logged in yet, in which case there are no
+	// profiles either.
+	_ = c.init()
+
+	name := c.activeProfile()
+	if name == "" {
+		return nil
+	}
+
+	managingProfiles := cmd.Parent() != nil && cmd.Parent().Use == "profiles"
+
+	p, ok := c.config.Profiles[name]
+	if !ok {
+		// Profiles can still be managed to fix the situation.
+		if managingProfiles {
+			return nil
+		}
+		return fmt.Errorf("Unable to find profile: %s; run 'auth0 profiles list' to see your profiles", name)
+	}
+
+	if p.Tenant != "" && !cmd.Flags().Changed("tenant") {
+		c.tenant = p.Tenant
+	}
+
+	if p.Format != "" && !cmd.Flags().Changed("format") {
+		c.format = p.Format
+	}
+
+	// Setting the flags marks them as changed, so profiles create would
+	// bundle the defaults of the active profile with the flags given.
+	if managingProfiles {
+		return nil
+	}
+
+	defaults := map[string]string{
+		"audience": p.Audience,
+	}
+	if p.NoInput {
+		defaults["no-input"] = "true"
+	}
+
+	for flag, value := range defaults {
+		if value == "" || cmd.Flags().Lookup(flag) == nil || cmd.Flags().Changed(flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// defaultAppID is the application to pick by default: the one of the active
+// profile, or else the one last used.
+func (c *cli) defaultAppID(t tenant) string {
+	if id := c.profileAppID(t); id != "" {
+		return id
+	}
+	return t.DefaultAppID
+}
+
+// profileAppID is the application of the active profile, if it's for the
+// given tenant.
+func (c *cli) profileAppID(t tenant) string {
+	p, ok := c.config.Profiles[c.activeProfile()]
+	if !ok || (p.Tenant != "" && p.Tenant != t.Domain) {
+		return ""
+	}
+	return p.AppID
+}
+
+func (c *cli) profileNames() []string {
+	names := make([]string, 0, len(c.config.Profiles))
+	for name := range c.config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *cli) profilePickerOptions() (pickerOptions, error) {
+	var priorityOpts, opts pickerOptions
+
+	for _, name := range c.profileNames() {
+		opt := pickerOption{value: name, label: name}
+
+		// check if this is currently the active profile.
+		if name == c.activeProfile() {
+			priorityOpts = append(priorityOpts, opt)
+		} else {
+			opts = append(opts, opt)
+		}
+	}
+
+	if len(opts)+len(priorityOpts) == 0 {
+		return nil, errNoProfiles
+	}
+
+	return append(priorityOpts, opts...), nil
+}
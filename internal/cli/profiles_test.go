@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProfile(t *testing.T) {
+	cfg := config{
+		DefaultTenant:  "default.auth0.com",
+		DefaultProfile: "travel0",
+		Tenants: map[string]tenant{
+			"default.auth0.com": {Domain: "default.auth0.com", DefaultAppID: "last-used"},
+			"travel0.auth0.com": {Domain: "travel0.auth0.com"},
+			"acme.auth0.com":    {Domain: "acme.auth0.com"},
+		},
+		Profiles: map[string]profile{
+			"travel0": {Tenant: "travel0.auth0.com", AppID: "travel0-app", Audience: "https://travel0.com/api", Format: "json", NoInput: true},
+			"acme":    {Tenant: "acme.auth0.com"},
+		},
+	}
+
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, ioutil.WriteFile(path, b, 0600))
+
+	type result struct {
+		tenant   string
+		format   string
+		noInput  bool
+		audience string
+		appID    string
+	}
+
+	run := func(t *testing.T, args ...string) (result, error) {
+		t.Helper()
+
+		cli := &cli{path: path, renderer: &display.Renderer{}}
+
+		var res result
+		root := &cobra.Command{
+			Use:           "auth0",
+			SilenceUsage:  true,
+			SilenceErrors: true,
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+				return cli.applyProfile(cmd)
+			},
+		}
+		addPersistentFlags(root, cli)
+
+		cmd := &cobra.Command{
+			Use: "token",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				res.tenant = cli.tenant
+				res.format = cli.format
+				res.noInput = cli.noInput
+				res.appID = cli.defaultAppID(cli.config.Tenants[cli.tenant])
+				return nil
+			},
+		}
+		cmd.Flags().StringVar(&res.audience, "audience", "", "")
+		root.AddCommand(cmd)
+
+		root.SetArgs(append([]string{"token"}, args...))
+		return res, root.Execute()
+	}
+
+	t.Run("applies the active profile", func(t *testing.T) {
+		res, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, result{
+			tenant:   "travel0.auth0.com",
+			format:   "json",
+			noInput:  true,
+			audience: "https://travel0.com/api",
+			appID:    "travel0-app",
+		}, res)
+	})
+
+	t.Run("explicit flags take precedence", func(t *testing.T) {
+		res, err := run(t, "--tenant", "default.auth0.com", "--audience", "https://other.com", "--no-input=false")
+		require.NoError(t, err)
+		assert.Equal(t, "default.auth0.com", res.tenant)
+		assert.Equal(t, "https://other.com", res.audience)
+		assert.False(t, res.noInput)
+		assert.Equal(t, "json", res.format)
+		assert.Equal(t, "last-used", res.appID, "the app of the profile is for another tenant")
+	})
+
+	t.Run("env var overrides the active profile", func(t *testing.T) {
+		os.Setenv("AUTH0_PROFILE", "acme")
+		defer os.Unsetenv("AUTH0_PROFILE")
+
+		res, err := run(t)
+		require.NoError(t, err)
+		assert.Equal(t, result{tenant: "acme.auth0.com"}, res)
+	})
+
+	t.Run("flag overrides the env var", func(t *testing.T) {
+		os.Setenv("AUTH0_PROFILE", "acme")
+		defer os.Unsetenv("AUTH0_PROFILE")
+
+		res, err := run(t, "--profile", "travel0")
+		require.NoError(t, err)
+		assert.Equal(t, "travel0.auth0.com", res.tenant)
+	})
+
+	t.Run("fails with an unknown profile", func(t *testing.T) {
+		_, err := run(t, "--profile", "nope")
+		assert.EqualError(t, err, "Unable to find profile: nope; run 'auth0 profiles list' to see your profiles")
+	})
+
+	t.Run("profiles are created without the defaults of the active profile", func(t *testing.T) {
+		b, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, ioutil.WriteFile(path, b, 0600))
+
+		cli := &cli{path: path, renderer: &display.Renderer{MessageWriter: ioutil.Discard}}
+
+		root := &cobra.Command{
+			Use:           "auth0",
+			SilenceUsage:  true,
+			SilenceErrors: true,
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+				return cli.applyProfile(cmd)
+			},
+		}
+		addPersistentFlags(root, cli)
+		root.AddCommand(profilesCmd(cli))
+
+		root.SetArgs([]string{"profiles", "create", "ci", "--tenant", "acme.auth0.com"})
+		require.NoError(t, root.Execute())
+
+		b, err = ioutil.ReadFile(path)
+		require.NoError(t, err)
+		var saved config
+		require.NoError(t, json.Unmarshal(b, &saved))
+		assert.Equal(t, profile{Tenant: "acme.auth0.com"}, saved.Profiles["ci"])
+	})
+
+	t.Run("doesn't read the config before config init", func(t *testing.T) {
+		cli := &cli{path: path, renderer: &display.Renderer{}}
+
+		root := &cobra.Command{
+			Use:           "auth0",
+			SilenceUsage:  true,
+			SilenceErrors: true,
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+				return cli.applyProfile(cmd)
+			},
+		}
+		addPersistentFlags(root, cli)
+
+		config := &cobra.Command{Use: "config"}
+		config.AddCommand(&cobra.Command{
+			Use:  "init",
+			RunE: func(cmd *cobra.Command, args []string) error { return nil },
+		})
+		root.AddCommand(config)
+
+		root.SetArgs([]string{"config", "init"})
+		require.NoError(t, root.Execute())
+		assert.Nil(t, cli.config.Tenants)
+		assert.Empty(t, cli.tenant)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/mholt/archiver/v3"
 	"github.com/spf13/cobra"
@@ -63,6 +64,30 @@ var (
 		Help:       "Tech/Language of the quickstart sample to download.",
 		IsRequired: true,
 	}
+
+	qsFromCache = Flag{
+		Name:     "From Cache",
+		LongForm: "from-cache",
+		Help:     "Extract a previously downloaded sample from the local cache, offline.",
+	}
+
+	qsSetup = Flag{
+		Name:     "Setup",
+		LongForm: "setup",
+		Help:     "Write the application credentials into the sample's config file and check it runs.",
+	}
+
+	qsSmokeTimeout = Flag{
+		Name:     "Smoke Check Timeout",
+		LongForm: "smoke-timeout",
+		Help:     "How long to wait for the sample's callback URL to be reachable when using --setup. Use 0 to skip the check.",
+	}
+
+	qsCatalogURL = Flag{
+		Name:     "URL",
+		LongForm: "url",
+		Help:     "URL to fetch the quickstarts catalog from, e.g. an internal mirror.",
+	}
 )
 
 func quickstartsCmd(cli *cli) *cobra.Command {
@@ -76,6 +101,7 @@ func quickstartsCmd(cli *cli) *cobra.Command {
 	cmd.SetUsageTemplate(resourceUsageTemplate())
 	cmd.AddCommand(listQuickstartsCmd(cli))
 	cmd.AddCommand(downloadQuickstartCmd(cli))
+	cmd.AddCommand(updateQuickstartsCmd(cli))
 
 	return cmd
 }
@@ -92,27 +118,97 @@ auth0 quickstarts ls
 auth0 qs list
 auth0 qs ls`,
 		Run: func(cmd *cobra.Command, args []string) {
-			cli.renderer.QuickstartList(quickstartsByType)
+			qs, _ := cli.quickstartCatalog()
+			cli.renderer.QuickstartList(qs)
+		},
+	}
+
+	return cmd
+}
+
+func updateQuickstartsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		URL string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Args:  cobra.NoArgs,
+		Short: "Refresh the list of available Quickstarts",
+		Long: `Refresh the list of available Quickstarts.
+
+The catalog embedded in the CLI is replaced by the latest one, which is stored
+in the CLI config directory and used by 'list' and 'download' from then on.`,
+		Example: `auth0 quickstarts update
+auth0 qs update --url https://mirror.example.com/quickstarts.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				qs      map[string][]auth0.Quickstart
+				version string
+			)
+
+			err := ansi.Waiting(func() error {
+				var err error
+				qs, version, err = cli.fetchQuickstartCatalog(cmd.Context(), inputs.URL)
+				return err
+			})
+
+			if err != nil {
+				return fmt.Errorf("Unable to update the quickstarts catalog: %w", err)
+			}
+
+			total := 0
+			for _, list := range qs {
+				total += len(list)
+			}
+
+			cli.renderer.Infof("Quickstarts catalog updated to version %s (%d quickstarts)", version, total)
+			return nil
 		},
 	}
 
+	qsCatalogURL.RegisterString(cmd, &inputs.URL, quickstartsCatalogURL)
 	return cmd
 }
 
 func downloadQuickstartCmd(cli *cli) *cobra.Command {
 	var inputs struct {
-		ClientID string
-		Stack    string
+		ClientID     string
+		Stack        string
+		FromCache    bool
+		Setup        bool
+		SmokeTimeout time.Duration
 	}
 
 	cmd := &cobra.Command{
 		Use:   "download",
 		Args:  cobra.MaximumNArgs(1),
 		Short: "Download a Quickstart sample app for a specific tech stack",
-		Long:  "Download a Quickstart sample app for a specific tech stack.",
+		Long: `Download a Quickstart sample app for a specific tech stack.
+
+Downloaded samples are kept in a local cache, use --from-cache to reuse them
+offline. It needs the application's client ID, and neither logs in nor reaches
+the quickstarts download service or the tenant.
+
+Use --setup to write the application credentials into the config file the
+sample expects and wait until the started sample answers on its callback URL.`,
 		Example: `auth0 quickstarts download --stack <stack>
-auth0 qs download --stack <stack>`,
+auth0 qs download --stack <stack>
+auth0 qs download <id> --stack <stack> --from-cache
+auth0 qs download --stack <stack> --setup`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Cached samples are meant for build agents, so they're
+			// extracted without prompting.
+			if inputs.FromCache {
+				if len(args) == 0 {
+					return errors.New("Specify the client ID of the application to use --from-cache, e.g. 'auth0 qs download <id> --from-cache'")
+				}
+				if inputs.Setup {
+					return errors.New("--setup needs the application's credentials from the tenant, so it can't be used with --from-cache")
+				}
+				return extractCachedQuickstart(cmd, cli, args[0], inputs.Stack)
+			}
+
 			if !canPrompt(cmd) {
 				return errors.New("This command can only be run on interactive mode")
 			}
@@ -137,9 +233,11 @@ auth0 qs download --stack <stack>`,
 				return fmt.Errorf("An unexpected error occurred, please verify your Client Id: %v", err.Error())
 			}
 
+			catalog, catalogVersion := cli.quickstartCatalog()
+
 			if inputs.Stack == "" {
 				// get the valid types for this App:
-				stacks, err := quickstartStacksFromType(client.GetAppType())
+				stacks, err := quickstartStacksFromType(catalog, client.GetAppType())
 				if err != nil {
 					return fmt.Errorf("An unexpected error occurred: %v", err)
 				}
@@ -149,24 +247,24 @@ auth0 qs download --stack <stack>`,
 				}
 			}
 
-			target, exists, err := quickstartPathFor(client)
+			target, exists, err := quickstartPathFor(client.GetName())
 			if err != nil {
 				return fmt.Errorf("An unexpected error occurred: %v", err)
 			}
 
-			if exists && !cli.force {
-				if confirmed := prompt.Confirm(fmt.Sprintf("WARNING: %s already exists.\n Are you sure you want to proceed?", target)); !confirmed {
-					return nil
-				}
+			if exists && !confirmQuickstartOverwrite(cli, target) {
+				return nil
 			}
 
-			quickstart, err := getQuickstart(client.GetAppType(), inputs.Stack)
+			quickstart, err := getQuickstart(catalog, client.GetAppType(), inputs.Stack)
 			if err != nil {
 				return fmt.Errorf("An unexpected error occurred with the specified stack %v: %v", inputs.Stack, err)
 			}
 
+			meta := quickstartSampleMetaFor(client, quickstart, catalogVersion)
+
 			err = ansi.Waiting(func() error {
-				return downloadQuickStart(cmd.Context(), client, target, quickstart)
+				return downloadQuickStart(cmd.Context(), cli, client, target, quickstart, meta)
 			})
 
 			if err != nil {
@@ -181,32 +279,86 @@ auth0 qs download --stack <stack>`,
 			}
 
 			qsSamplePath := path.Join(target, quickstart.Samples[0])
-			readme, err := loadQuickstartSampleReadme(qsSamplePath) // Some QS have non-markdown READMEs (eg auth0-python uses rst)
-
-			if err == nil {
-				cli.renderer.Markdown(readme)
-			} else {
-				cli.renderer.Infof("%s You might wanna check out the Quickstart sample README", ansi.Faint("Hint:"))
-			}
-
-			relativeQSSamplePath, err := relativeQuickstartSamplePath(qsSamplePath)
-			if err != nil {
-				return err
+			if inputs.Setup {
+				return runQuickstartSetup(cmd.Context(), cli, client, quickstart, qsSamplePath, inputs.SmokeTimeout)
 			}
 
-			cli.renderer.Infof("%s Start with 'cd %s'", ansi.Faint("Hint:"), relativeQSSamplePath)
-
-			return nil
+			return showQuickstartHints(cli, qsSamplePath)
 		},
 	}
 
 	cmd.SetUsageTemplate(resourceUsageTemplate())
 
 	qsStack.RegisterString(cmd, &inputs.Stack, "")
+	qsFromCache.RegisterBool(cmd, &inputs.FromCache, false)
+	qsSetup.RegisterBool(cmd, &inputs.Setup, false)
+	qsSmokeTimeout.RegisterDuration(cmd, &inputs.SmokeTimeout, 2*time.Minute)
 	return cmd
 }
 
-func downloadQuickStart(ctx context.Context, client *management.Client, target string, q auth0.Quickstart) error {
+// extractCachedQuickstart extracts the sample cached for an application,
+// without reaching the tenant, which is why the URLs of the application
+// aren't checked.
+func extractCachedQuickstart(cmd *cobra.Command, cli *cli, clientID, stack string) error {
+	archive, cached, err := cli.cachedQuickstartSample(clientID, stack)
+	if err != nil {
+		return err
+	}
+
+	if _, catalogVersion := cli.quickstartCatalog(); cached.CatalogVersion != catalogVersion {
+		cli.renderer.Warnf("The cached sample was downloaded with quickstarts catalog version %s, the current one is %s.", cached.CatalogVersion, catalogVersion)
+	}
+
+	name := cached.ClientName
+	if name == "" {
+		name = clientID
+	}
+
+	target, exists, err := quickstartPathFor(name)
+	if err != nil {
+		return fmt.Errorf("An unexpected error occurred: %v", err)
+	}
+
+	if exists && !cli.force && !canPrompt(cmd) {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", target)
+	}
+	if exists && !confirmQuickstartOverwrite(cli, target) {
+		return nil
+	}
+
+	if err := unarchiveQuickstart(archive, target); err != nil {
+		return fmt.Errorf("Unable to extract cached quickstart sample: %v", err)
+	}
+
+	cli.renderer.Infof("Quickstart sample from %s sucessfully extracted at %s", cached.DownloadedAt.Format(time.RFC1123), target)
+
+	return showQuickstartHints(cli, path.Join(target, cached.Sample))
+}
+
+func confirmQuickstartOverwrite(cli *cli, target string) bool {
+	return cli.force || prompt.Confirm(fmt.Sprintf("WARNING: %s already exists.\n Are you sure you want to proceed?", target))
+}
+
+func showQuickstartHints(cli *cli, qsSamplePath string) error {
+	readme, err := loadQuickstartSampleReadme(qsSamplePath) // Some QS have non-markdown READMEs (eg auth0-python uses rst)
+
+	if err == nil {
+		cli.renderer.Markdown(readme)
+	} else {
+		cli.renderer.Infof("%s You might wanna check out the Quickstart sample README", ansi.Faint("Hint:"))
+	}
+
+	relativeQSSamplePath, err := relativeQuickstartSamplePath(qsSamplePath)
+	if err != nil {
+		return err
+	}
+
+	cli.renderer.Infof("%s Start with 'cd %s'", ansi.Faint("Hint:"), relativeQSSamplePath)
+
+	return nil
+}
+
+func downloadQuickStart(ctx context.Context, cli *cli, client *management.Client, target string, q auth0.Quickstart, meta quickstartSampleMeta) error {
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, quickstartEndpoint, nil)
 	if err != nil {
 		return unexpectedError(err)
@@ -225,22 +377,18 @@ func downloadQuickStart(ctx context.Context, client *management.Client, target s
 	// to the GitHub username they're under.
 	params.Add("org", quickstartOrg)
 	params.Add("client_id", client.GetClientID())
-
-	// Callback URL, if not set, it will just take the default one.
-	callbackURL := quickstartDefaultCallbackURL
-	if list := urlsFor(client.Callbacks); len(list) > 0 {
-		callbackURL = list[0]
-	}
-	params.Add("callback_url", callbackURL)
+	params.Add("callback_url", quickstartCallbackURLFor(client))
 
 	request.URL.RawQuery = params.Encode()
 	request.Header.Set("Content-Type", quickstartContentType)
 
-	response, err := http.DefaultClient.Do(request)
+	response, err := cli.defaultClient().Do(request)
 	if err != nil {
 		return unexpectedError(err)
 	}
 
+	defer response.Body.Close()
+
 	if response.StatusCode != http.StatusOK {
 		return fmt.Errorf("Expected status %d, got %d", http.StatusOK, response.StatusCode)
 	}
@@ -260,25 +408,64 @@ func downloadQuickStart(ctx context.Context, client *management.Client, target s
 	}
 	defer os.Remove(tmpFile.Name())
 
+	// A failure to cache the sample shouldn't fail the download itself, it
+	// only means it won't be available with --from-cache later on.
+	meta.DownloadedAt = time.Now()
+	if err := cli.cacheQuickstartSample(meta, tmpFile.Name()); err != nil {
+		cli.renderer.Warnf("Unable to cache the quickstart sample: %v", err)
+	}
+
+	return unarchiveQuickstart(tmpFile.Name(), target)
+}
+
+func unarchiveQuickstart(archive, target string) error {
 	if err := os.RemoveAll(target); err != nil {
 		return unexpectedError(err)
 	}
 
-	if err := archiver.Unarchive(tmpFile.Name(), target); err != nil {
+	if err := archiver.Unarchive(archive, target); err != nil {
 		return unexpectedError(err)
 	}
 
 	return nil
 }
 
-func quickstartPathFor(client *management.Client) (p string, exists bool, err error) {
+// quickstartCallbackURLFor returns the callback URL embedded in the sample,
+// if not set in the client, it will just take the default one.
+func quickstartCallbackURLFor(client *management.Client) string {
+	if list := urlsFor(client.Callbacks); len(list) > 0 {
+		return list[0]
+	}
+	return quickstartDefaultCallbackURL
+}
+
+func quickstartSampleMetaFor(client *management.Client, q auth0.Quickstart, catalogVersion string) quickstartSampleMeta {
+	org := q.Org
+	if org == "" {
+		org = quickstartOrg
+	}
+
+	return quickstartSampleMeta{
+		Stack:          q.Name,
+		ClientName:     client.GetName(),
+		Org:            org,
+		Repo:           q.Repo,
+		Branch:         q.Branch,
+		Sample:         q.Samples[0],
+		ClientID:       client.GetClientID(),
+		CallbackURL:    quickstartCallbackURLFor(client),
+		CatalogVersion: catalogVersion,
+	}
+}
+
+func quickstartPathFor(name string) (p string, exists bool, err error) {
 	wd, err := os.Getwd()
 	if err != nil {
 		return "", false, err
 	}
 
 	re := regexp.MustCompile(`[^\w]+`)
-	friendlyName := re.ReplaceAllString(client.GetName(), "-")
+	friendlyName := re.ReplaceAllString(name, "-")
 	target := path.Join(wd, friendlyName)
 
 	exists = true
@@ -296,9 +483,9 @@ func quickstartPathFor(client *management.Client) (p string, exists bool, err er
 	return target, exists, nil
 }
 
-func getQuickstart(t, stack string) (auth0.Quickstart, error) {
+func getQuickstart(catalog map[string][]auth0.Quickstart, t, stack string) (auth0.Quickstart, error) {
 	qsType := quickstartsTypeFor(t)
-	quickstarts, ok := quickstartsByType[qsType]
+	quickstarts, ok := catalog[qsType]
 	if !ok {
 		return auth0.Quickstart{}, fmt.Errorf("Unknown quickstart type: %s", qsType)
 	}
@@ -310,14 +497,14 @@ func getQuickstart(t, stack string) (auth0.Quickstart, error) {
 	return auth0.Quickstart{}, fmt.Errorf("Quickstart not found for %s/%s", qsType, stack)
 }
 
-func quickstartStacksFromType(t string) ([]string, error) {
+func quickstartStacksFromType(catalog map[string][]auth0.Quickstart, t string) ([]string, error) {
 	qsType := quickstartsTypeFor(t)
-	_, ok := quickstartsByType[qsType]
+	_, ok := catalog[qsType]
 	if !ok {
 		return nil, fmt.Errorf("Unknown quickstart type: %s", qsType)
 	}
-	stacks := make([]string, 0, len(quickstartsByType[qsType]))
-	for _, s := range quickstartsByType[qsType] {
+	stacks := make([]string, 0, len(catalog[qsType]))
+	for _, s := range catalog[qsType] {
 		stacks = append(stacks, s.Name)
 	}
 	return stacks, nil
@@ -380,20 +567,20 @@ func promptDefaultURLs(cli *cli, client *management.Client, qsType string, qsSta
 		WebOrigins:        client.WebOrigins,
 	}
 
-	if !containsStr(client.Callbacks, defaultCallbackURL) {
+	if !containsValue(client.Callbacks, defaultCallbackURL) {
 		a.Callbacks = append(a.Callbacks, defaultCallbackURL)
 	}
 
-	if !containsStr(client.AllowedLogoutURLs, defaultURL) {
+	if !containsValue(client.AllowedLogoutURLs, defaultURL) {
 		a.AllowedLogoutURLs = append(a.AllowedLogoutURLs, defaultURL)
 	}
 
 	if strings.EqualFold(qsType, qsSpa) {
-		if !containsStr(client.AllowedOrigins, defaultURL) {
+		if !containsValue(client.AllowedOrigins, defaultURL) {
 			a.AllowedOrigins = append(a.AllowedOrigins, defaultURL)
 		}
 
-		if !containsStr(client.WebOrigins, defaultURL) {
+		if !containsValue(client.WebOrigins, defaultURL) {
 			a.WebOrigins = append(a.WebOrigins, defaultURL)
 		}
 	}
@@ -415,6 +602,11 @@ func promptDefaultURLs(cli *cli, client *management.Client, qsType string, qsSta
 			return err
 		}
 		cli.renderer.Infof("Application successfully updated")
+
+		client.Callbacks = a.Callbacks
+		client.AllowedLogoutURLs = a.AllowedLogoutURLs
+		client.AllowedOrigins = a.AllowedOrigins
+		client.WebOrigins = a.WebOrigins
 	}
 	return nil
 }
@@ -424,8 +616,8 @@ func promptDefaultURLs(cli *cli, client *management.Client, qsType string, qsSta
 func urlPromptFor(qsType string, qsStack string) string {
 	var p strings.Builder
 	p.WriteString("Quickstarts use localhost, do you want to add %s to the list\n of allowed callback URLs")
-	switch strings.ToLower(qsStack) {
-	case "next.js": // See https://github.com/auth0/auth0-cli/issues/200
+	switch {
+	case defaultCallbackURLFor(qsStack) != defaultURLFor(qsStack):
 		p.WriteString(" and %s to the list of allowed logout URLs?")
 		return fmt.Sprintf(p.String(), defaultCallbackURLFor(qsStack), defaultURLFor(qsStack))
 	default:
@@ -448,12 +640,12 @@ func defaultURLFor(s string) string {
 }
 
 func defaultCallbackURLFor(s string) string {
-	switch strings.ToLower(s) {
-	case "next.js": // See https://github.com/auth0/auth0-cli/issues/200
-		return fmt.Sprintf("%s/api/auth/callback", defaultURLFor(s))
-	default:
-		return defaultURLFor(s)
+	// The samples which can be set up tell where they're redirected to,
+	// e.g. Next.js, see https://github.com/auth0/auth0-cli/issues/200
+	if setup, ok := quickstartSetups[strings.ToLower(s)]; ok {
+		return setup.callbackURL(s)
 	}
+	return defaultURLFor(s)
 }
 
 func defaultURL(url string, port int) string {
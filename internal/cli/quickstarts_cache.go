@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+)
+
+const (
+	quickstartsCatalogURL      = `https://raw.githubusercontent.com/auth0/auth0-cli/main/internal/cli/data/quickstarts.json`
+	quickstartsCacheDir        = "quickstarts"
+	quickstartsCatalogFile     = "catalog.json"
+	quickstartsSamplesDir      = "samples"
+	quickstartsSampleFile      = "sample.zip"
+	quickstartsSampleMetaFile  = "meta.json"
+	quickstartsCatalogEmbedded = "embedded"
+)
+
+// quickstartSampleMeta describes a sample zip stored in the local cache. The
+// catalog version is recorded so that a refreshed catalog pointing to a new
+// branch or sample path doesn't silently reuse a stale archive, and the
+// application's name so that the sample can be extracted offline.
+type quickstartSampleMeta struct {
+	Stack          string    `json:"stack"`
+	ClientName     string    `json:"client_name,omitempty"`
+	Org            string    `json:"org"`
+	Repo           string    `json:"repo"`
+	Branch         string    `json:"branch"`
+	Sample         string    `json:"sample"`
+	ClientID       string    `json:"client_id"`
+	CallbackURL    string    `json:"callback_url"`
+	CatalogVersion string    `json:"catalog_version"`
+	DownloadedAt   time.Time `json:"downloaded_at"`
+}
+
+// quickstartsCachePath returns the directory the quickstarts catalog and
+// sample archives are cached in, which lives next to the CLI config file.
+func (c *cli) quickstartsCachePath() string {
+	configPath := c.path
+	if configPath == "" {
+		configPath = defaultConfigPath()
+	}
+
+	return filepath.Join(filepath.Dir(configPath), quickstartsCacheDir)
+}
+
+// quickstartCatalog loads the quickstarts catalog refreshed by
+// `auth0 quickstarts update`, falling back to the catalog embedded at build
+// time when there's no refreshed copy or it can't be parsed.
+func (c *cli) quickstartCatalog() (map[string][]auth0.Quickstart, string) {
+	buf, err := ioutil.ReadFile(filepath.Join(c.quickstartsCachePath(), quickstartsCatalogFile))
+	if err != nil {
+		return quickstartsByType, quickstartsCatalogEmbedded
+	}
+
+	qs, err := parseQuickstartCatalog(buf)
+	if err != nil {
+		c.renderer.Warnf("Ignoring the cached quickstarts catalog: %v", err)
+		return quickstartsByType, quickstartsCatalogEmbedded
+	}
+
+	return qs, quickstartCatalogVersion(buf)
+}
+
+func parseQuickstartCatalog(buf []byte) (map[string][]auth0.Quickstart, error) {
+	var qs map[string][]auth0.Quickstart
+	if err := json.Unmarshal(buf, &qs); err != nil {
+		return nil, fmt.Errorf("failed to parse the quickstarts catalog: %w", err)
+	}
+
+	if len(qs) == 0 {
+		return nil, errors.New("the quickstarts catalog is empty")
+	}
+
+	for qsType, list := range qs {
+		for _, q := range list {
+			if q.Name == "" || q.Repo == "" || len(q.Samples) == 0 {
+				return nil, fmt.Errorf("the quickstarts catalog has an incomplete %s entry: %q", qsType, q.Name)
+			}
+		}
+	}
+
+	return qs, nil
+}
+
+func quickstartCatalogVersion(buf []byte) string {
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// fetchQuickstartCatalog downloads a catalog from the given URL and stores it
+// in the cache directory once it has been validated.
+func (c *cli) fetchQuickstartCatalog(ctx context.Context, url string) (map[string][]auth0.Quickstart, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", unexpectedError(err)
+	}
+
+	response, err := c.defaultClient().Do(request)
+	if err != nil {
+		return nil, "", unexpectedError(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("Expected status %d, got %d", http.StatusOK, response.StatusCode)
+	}
+
+	buf, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", unexpectedError(err)
+	}
+
+	qs, err := parseQuickstartCatalog(buf)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dir := c.quickstartsCachePath()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, "", unexpectedError(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, quickstartsCatalogFile), buf, 0600); err != nil {
+		return nil, "", unexpectedError(err)
+	}
+
+	return qs, quickstartCatalogVersion(buf), nil
+}
+
+// quickstartSampleCacheKey identifies a sample archive. The quickstart
+// download service embeds the client ID and callback URL in the archive, so
+// they're part of the key along with the sample's origin.
+func quickstartSampleCacheKey(m quickstartSampleMeta) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		m.Org, m.Repo, m.Branch, m.Sample, m.ClientID, m.CallbackURL,
+	}, "\n")))
+
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func (c *cli) quickstartSamplePath(m quickstartSampleMeta) string {
+	return filepath.Join(c.quickstartsCachePath(), quickstartsSamplesDir, quickstartSampleCacheKey(m))
+}
+
+// cacheQuickstartSample copies a downloaded sample archive into the cache.
+func (c *cli) cacheQuickstartSample(m quickstartSampleMeta, archive string) error {
+	dir := c.quickstartSamplePath(m)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	src, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(filepath.Join(dir, quickstartsSampleFile), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+
+	if err := dst.Close(); err != nil {
+		return err
+	}
+
+	buf, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, quickstartsSampleMetaFile), buf, 0600)
+}
+
+// cachedQuickstartSample returns the path to the latest sample archive cached
+// for an application, and the metadata it was stored with. The stack can be
+// left empty to match any.
+func (c *cli) cachedQuickstartSample(clientID, stack string) (string, quickstartSampleMeta, error) {
+	dir := filepath.Join(c.quickstartsCachePath(), quickstartsSamplesDir)
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", quickstartSampleMeta{}, err
+	}
+
+	var (
+		found  string
+		latest quickstartSampleMeta
+	)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		buf, err := ioutil.ReadFile(filepath.Join(dir, e.Name(), quickstartsSampleMetaFile))
+		if err != nil {
+			continue
+		}
+
+		var m quickstartSampleMeta
+		if err := json.Unmarshal(buf, &m); err != nil {
+			continue
+		}
+
+		if m.ClientID != clientID || (stack != "" && m.Stack != stack) {
+			continue
+		}
+
+		if found == "" || m.DownloadedAt.After(latest.DownloadedAt) {
+			found, latest = e.Name(), m
+		}
+	}
+
+	if found == "" {
+		what := "the application " + clientID
+		if stack != "" {
+			what = stack + " and " + what
+		}
+		return "", latest, fmt.Errorf("No cached sample found for %s; run 'auth0 quickstarts download' without --from-cache while online first", what)
+	}
+
+	archive := filepath.Join(dir, found, quickstartsSampleFile)
+	if _, err := os.Stat(archive); err != nil {
+		return "", latest, fmt.Errorf("the cached sample for %s is incomplete: %w", latest.Stack, err)
+	}
+
+	return archive, latest, nil
+}
@@ -0,0 +1,375 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
+)
+
+const (
+	qsConfigFormatJSON = "json"
+	qsConfigFormatEnv  = "env"
+
+	qsSmokeCheckInterval = 2 * time.Second
+	qsSecretSize         = 32
+)
+
+// quickstartSetup describes where a quickstart sample expects its Auth0
+// settings and how to start it.
+type quickstartSetup struct {
+	ConfigFile string
+	Format     string
+	Run        string
+	Values     func(s quickstartSetupSettings) map[string]string
+
+	// CallbackPath is where the sample is redirected to after login,
+	// relative to its base URL. Single page apps use the base URL.
+	CallbackPath string
+}
+
+// callbackURL is the callback URL of the sample when it listens on the
+// default URL of the stack.
+func (s quickstartSetup) callbackURL(stack string) string {
+	return defaultURLFor(stack) + s.CallbackPath
+}
+
+// quickstartSetupSettings are the values written into a sample's config file.
+type quickstartSetupSettings struct {
+	Domain       string
+	ClientID     string
+	ClientSecret string
+	BaseURL      string
+	CallbackURL  string
+	Secret       string
+}
+
+func spaQuickstartSetup(configFile, run string) quickstartSetup {
+	return quickstartSetup{
+		ConfigFile: configFile,
+		Format:     qsConfigFormatJSON,
+		Run:        run,
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"domain":   s.Domain,
+				"clientId": s.ClientID,
+			}
+		},
+	}
+}
+
+// quickstartSetups is keyed by the lowercase stack name, as it's found in the
+// quickstarts catalog.
+var quickstartSetups = map[string]quickstartSetup{
+	"angular":    spaQuickstartSetup("auth_config.json", "npm install && npm start"),
+	"javascript": spaQuickstartSetup("auth_config.json", "npm install && npm start"),
+	"react":      spaQuickstartSetup("src/auth_config.json", "npm install && npm start"),
+	"vue":        spaQuickstartSetup("auth_config.json", "npm install && npm run serve"),
+	"express": {
+		ConfigFile:   ".env",
+		Format:       qsConfigFormatEnv,
+		Run:          "npm install && npm start",
+		CallbackPath: "/callback",
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"ISSUER_BASE_URL": "https://" + s.Domain,
+				"CLIENT_ID":       s.ClientID,
+				"CLIENT_SECRET":   s.ClientSecret,
+				"BASE_URL":        s.BaseURL,
+				"SECRET":          s.Secret,
+			}
+		},
+	},
+	"next.js": {
+		ConfigFile:   ".env.local",
+		Format:       qsConfigFormatEnv,
+		Run:          "npm install && npm run dev",
+		CallbackPath: "/api/auth/callback",
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"AUTH0_SECRET":          s.Secret,
+				"AUTH0_BASE_URL":        s.BaseURL,
+				"AUTH0_ISSUER_BASE_URL": "https://" + s.Domain,
+				"AUTH0_CLIENT_ID":       s.ClientID,
+				"AUTH0_CLIENT_SECRET":   s.ClientSecret,
+			}
+		},
+	},
+	"python": {
+		ConfigFile:   ".env",
+		Format:       qsConfigFormatEnv,
+		Run:          "pip install -r requirements.txt && python server.py",
+		CallbackPath: "/callback",
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"AUTH0_DOMAIN":        s.Domain,
+				"AUTH0_CLIENT_ID":     s.ClientID,
+				"AUTH0_CLIENT_SECRET": s.ClientSecret,
+				"APP_SECRET_KEY":      s.Secret,
+			}
+		},
+	},
+	"django": {
+		ConfigFile:   ".env",
+		Format:       qsConfigFormatEnv,
+		Run:          "pip install -r requirements.txt && python manage.py runserver 3000",
+		CallbackPath: "/callback",
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"AUTH0_DOMAIN":        s.Domain,
+				"AUTH0_CLIENT_ID":     s.ClientID,
+				"AUTH0_CLIENT_SECRET": s.ClientSecret,
+			}
+		},
+	},
+	"go": {
+		ConfigFile:   ".env",
+		Format:       qsConfigFormatEnv,
+		Run:          "go run main.go",
+		CallbackPath: "/callback",
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"AUTH0_DOMAIN":        s.Domain,
+				"AUTH0_CLIENT_ID":     s.ClientID,
+				"AUTH0_CLIENT_SECRET": s.ClientSecret,
+				"AUTH0_CALLBACK_URL":  s.CallbackURL,
+			}
+		},
+	},
+	"php": {
+		ConfigFile:   ".env",
+		Format:       qsConfigFormatEnv,
+		Run:          "composer install && php -S localhost:3000 index.php",
+		CallbackPath: "/callback",
+		Values: func(s quickstartSetupSettings) map[string]string {
+			return map[string]string{
+				"AUTH0_DOMAIN":        s.Domain,
+				"AUTH0_CLIENT_ID":     s.ClientID,
+				"AUTH0_CLIENT_SECRET": s.ClientSecret,
+				"AUTH0_COOKIE_SECRET": s.Secret,
+				"AUTH0_BASE_URL":      s.BaseURL,
+			}
+		},
+	},
+}
+
+// quickstartSetupFor returns the setup instructions of a quickstart, if the
+// stack supports being configured automatically.
+func quickstartSetupFor(q auth0.Quickstart) (quickstartSetup, bool) {
+	s, ok := quickstartSetups[strings.ToLower(q.Name)]
+	return s, ok
+}
+
+// setupQuickstart writes the application credentials into the config file
+// the sample expects, returning the path of the written file.
+func setupQuickstart(samplePath string, setup quickstartSetup, settings quickstartSetupSettings) (string, error) {
+	target := filepath.Join(samplePath, filepath.FromSlash(setup.ConfigFile))
+	values := setup.Values(settings)
+
+	existing, err := ioutil.ReadFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	var buf []byte
+	err = nil
+	switch setup.Format {
+	case qsConfigFormatJSON:
+		buf, err = mergeJSONConfig(existing, values)
+	case qsConfigFormatEnv:
+		buf = []byte(mergeEnvConfig(string(existing), values))
+	default:
+		err = fmt.Errorf("unknown config format: %s", setup.Format)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(target, buf, 0600); err != nil {
+		return "", err
+	}
+
+	return target, nil
+}
+
+// mergeJSONConfig sets the given keys on a JSON object, keeping the other
+// keys the sample ships with.
+func mergeJSONConfig(existing []byte, values map[string]string) ([]byte, error) {
+	config := map[string]interface{}{}
+	if len(strings.TrimSpace(string(existing))) > 0 {
+		if err := json.Unmarshal(existing, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse the existing config: %w", err)
+		}
+	}
+
+	for k, v := range values {
+		config[k] = v
+	}
+
+	buf, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, '\n'), nil
+}
+
+// mergeEnvConfig sets the given keys in a dotenv file, replacing existing
+// assignments in place and appending the missing ones.
+func mergeEnvConfig(existing string, values map[string]string) string {
+	written := map[string]bool{}
+
+	var lines []string
+	if existing != "" {
+		lines = strings.Split(strings.TrimRight(existing, "\n"), "\n")
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key := strings.TrimSpace(strings.SplitN(strings.TrimPrefix(trimmed, "export "), "=", 2)[0])
+		if v, ok := values[key]; ok {
+			lines[i] = fmt.Sprintf("%s=%s", key, quoteEnvValue(v))
+			written[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		if !written[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, quoteEnvValue(values[k])))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func quoteEnvValue(v string) string {
+	if strings.ContainsAny(v, " #\"'") {
+		return fmt.Sprintf("%q", v)
+	}
+	return v
+}
+
+// waitForQuickstart polls the sample's callback URL until it answers. Any
+// HTTP response counts, the goal is to check the sample is up and listening
+// where Auth0 will redirect to, not that the callback succeeds on its own.
+func waitForQuickstart(ctx context.Context, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := &http.Client{
+		Timeout: qsSmokeCheckInterval,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	t := time.NewTicker(qsSmokeCheckInterval)
+	defer t.Stop()
+
+	for {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		if response, err := client.Do(request); err == nil {
+			response.Body.Close()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s was not reachable after %s", url, timeout)
+		case <-t.C:
+		}
+	}
+}
+
+// runQuickstartSetup configures a downloaded sample for the given client and
+// checks that it's reachable once started.
+func runQuickstartSetup(ctx context.Context, cli *cli, client *management.Client, q auth0.Quickstart, samplePath string, smokeTimeout time.Duration) error {
+	setup, ok := quickstartSetupFor(q)
+	if !ok {
+		cli.renderer.Warnf("Automatic setup isn't available for %s yet, follow the sample README to configure it.", q.Name)
+		return nil
+	}
+
+	// The sample listens on the default URLs, so Auth0 must allow its
+	// callback for the login to work.
+	callbackURL := setup.callbackURL(q.Name)
+	if !containsValue(client.Callbacks, callbackURL) {
+		return fmt.Errorf("The application doesn't allow the callback URL %s the sample uses; accept adding the default URLs, or add it to the Allowed Callback URLs with 'auth0 apps update %s --callbacks'", callbackURL, client.GetClientID())
+	}
+
+	secret, err := generateState(qsSecretSize)
+	if err != nil {
+		return unexpectedError(err)
+	}
+
+	settings := quickstartSetupSettings{
+		Domain:       cli.tenant,
+		ClientID:     client.GetClientID(),
+		ClientSecret: client.GetClientSecret(),
+		BaseURL:      defaultURLFor(q.Name),
+		CallbackURL:  callbackURL,
+		Secret:       secret,
+	}
+
+	configFile, err := setupQuickstart(samplePath, setup, settings)
+	if err != nil {
+		return fmt.Errorf("Unable to configure the quickstart sample: %w", err)
+	}
+
+	relativeConfigFile, err := relativeQuickstartSamplePath(configFile)
+	if err != nil {
+		return err
+	}
+	cli.renderer.Infof("Application credentials written to %s", relativeConfigFile)
+
+	if smokeTimeout <= 0 {
+		return nil
+	}
+
+	relativeSamplePath, err := relativeQuickstartSamplePath(samplePath)
+	if err != nil {
+		return err
+	}
+
+	cli.renderer.Infof("Start the sample in another terminal with 'cd %s && %s'", relativeSamplePath, setup.Run)
+
+	err = ansi.Spinner(fmt.Sprintf("Waiting for %s to be reachable", settings.CallbackURL), func() error {
+		return waitForQuickstart(ctx, settings.CallbackURL, smokeTimeout)
+	})
+	if err != nil {
+		return fmt.Errorf("Quickstart smoke check failed: %w", err)
+	}
+
+	cli.renderer.Infof("The quickstart sample is up, log in at %s", settings.BaseURL)
+	return nil
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickstartCatalog(t *testing.T) {
+	t.Run("falls back to the embedded catalog", func(t *testing.T) {
+		cli := &cli{path: filepath.Join(t.TempDir(), "config.json"), renderer: &display.Renderer{MessageWriter: ioutil.Discard}}
+
+		qs, version := cli.quickstartCatalog()
+		assert.Equal(t, quickstartsCatalogEmbedded, version)
+		assert.Equal(t, len(quickstartsByType), len(qs))
+	})
+
+	t.Run("prefers the refreshed catalog", func(t *testing.T) {
+		cli := &cli{path: filepath.Join(t.TempDir(), "config.json"), renderer: &display.Renderer{MessageWriter: ioutil.Discard}}
+
+		buf := []byte(`{"spa": [{"name": "Vue", "path": "vuejs", "samples": ["01-Login"], "org": "auth0-samples", "repo": "auth0-vue-samples"}]}`)
+		assert.NoError(t, os.MkdirAll(cli.quickstartsCachePath(), 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(cli.quickstartsCachePath(), quickstartsCatalogFile), buf, 0600))
+
+		qs, version := cli.quickstartCatalog()
+		assert.Equal(t, quickstartCatalogVersion(buf), version)
+		assert.Len(t, qs["spa"], 1)
+		assert.Equal(t, "Vue", qs["spa"][0].Name)
+	})
+
+	t.Run("rejects incomplete catalogs", func(t *testing.T) {
+		_, err := parseQuickstartCatalog([]byte(`{"spa": [{"name": "Vue"}]}`))
+		assert.Error(t, err)
+
+		_, err = parseQuickstartCatalog([]byte(`{}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestQuickstartSampleCache(t *testing.T) {
+	cli := &cli{path: filepath.Join(t.TempDir(), "config.json")}
+
+	meta := quickstartSampleMeta{
+		Stack:       "Vue",
+		ClientName:  "My App",
+		Org:         "auth0-samples",
+		Repo:        "auth0-vue-samples",
+		Branch:      "master",
+		Sample:      "01-Login",
+		ClientID:    "some-id",
+		CallbackURL: "http://localhost:3000",
+	}
+
+	_, _, err := cli.cachedQuickstartSample("some-id", "Vue")
+	assert.Error(t, err)
+
+	archive := filepath.Join(t.TempDir(), "sample.zip")
+	assert.NoError(t, ioutil.WriteFile(archive, []byte("zip"), 0600))
+	assert.NoError(t, cli.cacheQuickstartSample(meta, archive))
+
+	cachedArchive, cached, err := cli.cachedQuickstartSample("some-id", "Vue")
+	assert.NoError(t, err)
+	assert.Equal(t, meta.Repo, cached.Repo)
+	assert.Equal(t, "My App", cached.ClientName)
+
+	// The stack can be omitted, and the latest sample is used.
+	newer := meta
+	newer.Stack = "Angular"
+	newer.DownloadedAt = time.Now()
+	assert.NoError(t, cli.cacheQuickstartSample(newer, archive))
+
+	_, cached, err = cli.cachedQuickstartSample("some-id", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Angular", cached.Stack)
+
+	_, _, err = cli.cachedQuickstartSample("some-id", "React")
+	assert.Error(t, err)
+
+	buf, err := ioutil.ReadFile(cachedArchive)
+	assert.NoError(t, err)
+	assert.Equal(t, "zip", string(buf))
+
+	// A different client gets its own archive since the sample embeds its
+	// credentials.
+	_, _, err = cli.cachedQuickstartSample("other-id", "Vue")
+	assert.Error(t, err)
+}
+
+func TestMergeEnvConfig(t *testing.T) {
+	existing := "# Auth0 settings\nAUTH0_DOMAIN=\nexport AUTH0_CLIENT_ID={CLIENT_ID}\nPORT=3000\n"
+
+	got := mergeEnvConfig(existing, map[string]string{
+		"AUTH0_DOMAIN":        "travel0.auth0.com",
+		"AUTH0_CLIENT_ID":     "some-id",
+		"AUTH0_CLIENT_SECRET": "some secret",
+	})
+
+	assert.Equal(t, "# Auth0 settings\nAUTH0_DOMAIN=travel0.auth0.com\nAUTH0_CLIENT_ID=some-id\nPORT=3000\nAUTH0_CLIENT_SECRET=\"some secret\"\n", got)
+}
+
+func TestSetupQuickstart(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "src", "auth_config.json"), []byte(`{"domain": "", "audience": "YOUR_API"}`), 0600))
+
+	setup, ok := quickstartSetupFor(auth0.Quickstart{Name: "React"})
+	assert.True(t, ok)
+
+	target, err := setupQuickstart(dir, setup, quickstartSetupSettings{Domain: "travel0.auth0.com", ClientID: "some-id"})
+	assert.NoError(t, err)
+
+	buf, err := ioutil.ReadFile(target)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"domain": "travel0.auth0.com", "clientId": "some-id", "audience": "YOUR_API"}`, string(buf))
+
+	setup, ok = quickstartSetupFor(auth0.Quickstart{Name: "Express"})
+	assert.True(t, ok)
+
+	target, err = setupQuickstart(dir, setup, quickstartSetupSettings{Domain: "travel0.auth0.com", ClientID: "some-id"})
+	assert.NoError(t, err, "the sample doesn't ship the file")
+	assert.FileExists(t, target)
+
+	_, ok = quickstartSetupFor(auth0.Quickstart{Name: "Android"})
+	assert.False(t, ok)
+}
+
+func TestRunQuickstartSetupChecksCallback(t *testing.T) {
+	cli := &cli{renderer: &display.Renderer{MessageWriter: ioutil.Discard}, tenant: "travel0.auth0.com"}
+	q := auth0.Quickstart{Name: "React"}
+	dir := t.TempDir()
+
+	client := &management.Client{ClientID: auth0.String("some-id"), Callbacks: []interface{}{"https://example.com/callback"}}
+	err := runQuickstartSetup(context.Background(), cli, client, q, dir, 0)
+	assert.EqualError(t, err, "The application doesn't allow the callback URL http://localhost:3000 the sample uses; accept adding the default URLs, or add it to the Allowed Callback URLs with 'auth0 apps update some-id --callbacks'")
+
+	client.Callbacks = append(client.Callbacks, defaultCallbackURLFor(q.Name))
+	assert.NoError(t, runQuickstartSetup(context.Background(), cli, client, q, dir, 0))
+}
+
+func TestRunQuickstartSetupWritesCallback(t *testing.T) {
+	cli := &cli{renderer: &display.Renderer{MessageWriter: ioutil.Discard}, tenant: "travel0.auth0.com"}
+	q := auth0.Quickstart{Name: "Go"}
+	dir := t.TempDir()
+
+	client := &management.Client{ClientID: auth0.String("some-id"), Callbacks: []interface{}{"http://localhost:3000"}}
+	err := runQuickstartSetup(context.Background(), cli, client, q, dir, 0)
+	assert.EqualError(t, err, "The application doesn't allow the callback URL http://localhost:3000/callback the sample uses; accept adding the default URLs, or add it to the Allowed Callback URLs with 'auth0 apps update some-id --callbacks'")
+
+	assert.Equal(t, "http://localhost:3000/callback", defaultCallbackURLFor(q.Name), "the default URLs offered are the ones checked")
+	client.Callbacks = append(client.Callbacks, defaultCallbackURLFor(q.Name))
+	require.NoError(t, runQuickstartSetup(context.Background(), cli, client, q, dir, 0))
+
+	buf, err := ioutil.ReadFile(filepath.Join(dir, ".env"))
+	require.NoError(t, err)
+	assert.Contains(t, string(buf), "AUTH0_CALLBACK_URL=http://localhost:3000/callback\n")
+}
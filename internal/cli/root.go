@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"time"
@@ -57,7 +58,7 @@ func Execute() {
 			// panic for so we have less surprises. For
 			// non-developers, we'll swallow the panics.
 			if instrumentation.ReportException(err) {
-				fmt.Println(panicMessage)
+				fmt.Print(panicMessage)
 			} else {
 				panic(v)
 			}
@@ -70,7 +71,12 @@ func Execute() {
 	ansi.InitConsole()
 
 	cancelCtx := contextWithCancel()
-	if err := rootCmd.ExecuteContext(cancelCtx); err != nil {
+	err := rootCmd.ExecuteContext(cancelCtx)
+
+	// The trace is most useful when the command failed.
+	cli.writeTraceFile()
+
+	if err != nil {
 		cli.renderer.Heading("error")
 		cli.renderer.Errorf(err.Error())
 
@@ -97,12 +103,29 @@ func buildRootCmd(cli *cli) *cobra.Command {
 				return fmt.Errorf("could not decode env: %w", err)
 			}
 
+			// The active profile provides the defaults of the flags,
+			// so it must be applied before they're used.
+			if err := cli.applyProfile(cmd); err != nil {
+				return err
+			}
+
+			// An invalid network config can still be shown and cleared.
+			fixingNetwork := cmd.Parent().Use == "network" && cmd.Use != "set"
+			if err := cli.configureNetwork(); err != nil && !fixingNetwork {
+				return err
+			}
+			if cli.tracker != nil {
+				cli.tracker.Client = &http.Client{Transport: cli.netTransport()}
+			}
+
 			cli.authenticator = &auth.Authenticator{
 				Audience:           authCfg.Audience,
 				ClientID:           authCfg.ClientID,
 				DeviceCodeEndpoint: authCfg.DeviceCodeEndpoint,
 				OauthTokenEndpoint: authCfg.OauthTokenEndpoint,
+				Client:             cli.defaultClient(),
 			}
+
 			ansi.DisableColors = cli.noColor
 			prepareInteractivity(cmd)
 
@@ -141,6 +164,53 @@ func buildRootCmd(cli *cli) *cobra.Command {
 				return nil
 			}
 
+			// Extracting a cached quickstart sample is done offline.
+			if cmd.Use == "download" && cmd.Parent().Use == "quickstarts" {
+				if fromCache, _ := cmd.Flags().GetBool("from-cache"); fromCache {
+					return nil
+				}
+			}
+
+			// Refreshing the quickstarts catalog doesn't need a tenant.
+			if cmd.Use == "update" && cmd.Parent().Use == "quickstarts" {
+				return nil
+			}
+
+			// Checking or rendering a local page template is done offline.
+			if cmd.Parent().Use == "templates" && (cmd.Use == "lint" || cmd.Use == "render") && cmd.Flags().Changed("file") {
+				return nil
+			}
+
+			// Previewing a local email template is done offline.
+			if cmd.Use == "preview" && cmd.Parent().Use == "emails" && cmd.Flags().Changed("file") {
+				return nil
+			}
+
+			// Aggregating a local archive of logs is done offline.
+			if cmd.Use == "stats" && cmd.Parent().Use == "logs" && cmd.Flags().Changed("file") {
+				return nil
+			}
+
+			// Receiving log stream events doesn't involve the tenant.
+			if cmd.Use == "listen" && cmd.Parent().Use == "streams" {
+				return nil
+			}
+
+			// Managing where secrets are stored shouldn't trigger a login.
+			if cmd.Parent().Use == "secrets" && cmd.Parent().Parent().Use == "config" {
+				return nil
+			}
+
+			// Configuring the network is needed to log in to begin with.
+			if cmd.Parent().Use == "network" && cmd.Parent().Parent().Use == "config" {
+				return nil
+			}
+
+			// Managing profiles shouldn't trigger a login.
+			if cmd.Parent().Use == "profiles" {
+				return nil
+			}
+
 			// config init shouldn't trigger a login.
 			if cmd.CalledAs() == "init" && cmd.Parent().Use == "config" {
 				return nil
@@ -149,7 +219,7 @@ func buildRootCmd(cli *cli) *cobra.Command {
 			// Initialize everything once. Later callers can then
 			// freely assume that config is fully primed and ready
 			// to go.
-			return cli.setup(cmd.Context())
+			return cli.setup(cmd.Context(), scopesFor(cmd))
 		},
 	}
 
@@ -175,6 +245,29 @@ func addPersistentFlags(rootCmd *cobra.Command, cli *cli) {
 	rootCmd.PersistentFlags().BoolVar(&cli.noColor,
 		"no-color", false, "Disable colors.")
 
+	rootCmd.PersistentFlags().StringVar(&cli.profile,
+		"profile", "", "Profile to use, overriding the active one. Can also be set with AUTH0_PROFILE.")
+
+	rootCmd.PersistentFlags().StringVar(&cli.traceFile,
+		"trace-file", "", "Record the API requests to a HAR file, with credentials redacted.")
+
+	rootCmd.PersistentFlags().StringVar(&cli.caFile,
+		"ca-file", "", "PEM bundle of CA certificates to trust, e.g. those of a proxy inspecting TLS.")
+
+	rootCmd.PersistentFlags().StringVar(&cli.clientCert,
+		"client-cert", "", "PEM client certificate presented to servers requiring mTLS.")
+
+	rootCmd.PersistentFlags().StringVar(&cli.clientKey,
+		"client-key", "", "PEM key of the client certificate.")
+
+	// Fixtures are meant for testing the CLI itself.
+	rootCmd.PersistentFlags().StringVar(&cli.recordDir,
+		"record", "", "Record the Management API requests and responses to fixtures in the directory.")
+	rootCmd.PersistentFlags().StringVar(&cli.replayDir,
+		"replay", "", "Respond to the Management API requests with the fixtures of the directory, without sending them or logging in.")
+	_ = rootCmd.PersistentFlags().MarkHidden("record")
+	_ = rootCmd.PersistentFlags().MarkHidden("replay")
+
 }
 
 func addSubcommands(rootCmd *cobra.Command, cli *cli) {
@@ -185,6 +278,7 @@ func addSubcommands(rootCmd *cobra.Command, cli *cli) {
 	rootCmd.AddCommand(logoutCmd(cli))
 	rootCmd.AddCommand(configCmd(cli))
 	rootCmd.AddCommand(tenantsCmd(cli))
+	rootCmd.AddCommand(profilesCmd(cli))
 	rootCmd.AddCommand(appsCmd(cli))
 	rootCmd.AddCommand(usersCmd(cli))
 	rootCmd.AddCommand(rulesCmd(cli))
@@ -194,9 +288,11 @@ func addSubcommands(rootCmd *cobra.Command, cli *cli) {
 	rootCmd.AddCommand(organizationsCmd(cli))
 	rootCmd.AddCommand(brandingCmd(cli))
 	rootCmd.AddCommand(ipsCmd(cli))
+	rootCmd.AddCommand(protectionCmd(cli))
 	rootCmd.AddCommand(quickstartsCmd(cli))
 	rootCmd.AddCommand(testCmd(cli))
 	rootCmd.AddCommand(logsCmd(cli))
+	rootCmd.AddCommand(apiCmd(cli))
 
 	// keep completion at the bottom:
 	rootCmd.AddCommand(completionCmd(cli))
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/auth0/auth0-cli/internal/iostream"
+	"github.com/auth0/auth0-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+// commandScopes declares the Management API scopes each command needs, so that
+// a login with fewer scopes fails early rather than with a 403 halfway
+// through. Commands which don't call the Management API need none.
+var commandScopes = map[string][]string{
+	// The scopes depend on the endpoint called.
+	"api": nil,
+
+	"actions create": {"create:actions"},
+	"actions delete": {"read:actions", "delete:actions"},
+	"actions deploy": {"read:actions", "create:actions"},
+	"actions list":   {"read:actions"},
+	"actions open":   {"read:actions"},
+	"actions show":   {"read:actions"},
+	"actions update": {"read:actions", "update:actions"},
+
+	"apis create":      {"create:resource_servers"},
+	"apis delete":      {"read:resource_servers", "delete:resource_servers"},
+	"apis list":        {"read:resource_servers"},
+	"apis open":        {"read:resource_servers"},
+	"apis scopes list": {"read:resource_servers"},
+	"apis show":        {"read:resource_servers"},
+	"apis update":      {"read:resource_servers", "update:resource_servers"},
+
+	"apps create": {"create:clients"},
+	"apps delete": {"read:clients", "delete:clients"},
+	"apps list":   {"read:clients"},
+	"apps open":   {"read:clients"},
+	"apps show":   {"read:clients"},
+	"apps update": {"read:clients", "update:clients"},
+	"apps use":    {"read:clients"},
+
+	"branding show":                   {"read:branding"},
+	"branding update":                 {"read:branding", "update:branding"},
+	"branding domains check":          {"read:custom_domains", "create:custom_domains"},
+	"branding domains create":         {"create:custom_domains"},
+	"branding domains delete":         {"read:custom_domains", "delete:custom_domains"},
+	"branding domains list":           {"read:custom_domains"},
+	"branding domains show":           {"read:custom_domains"},
+	"branding domains update":         {"read:custom_domains", "update:custom_domains"},
+	"branding domains verify":         {"read:custom_domains", "create:custom_domains"},
+	"branding emails preview":         {"read:email_templates", "read:tenant_settings"},
+	"branding emails show":            {"read:email_templates"},
+	"branding emails update":          {"read:email_templates", "update:email_templates"},
+	"branding emails provider create": {"create:email_provider"},
+	"branding emails provider delete": {"delete:email_provider"},
+	"branding emails provider show":   {"read:email_provider"},
+	"branding emails provider test":   {"read:email_provider", "read:users", "update:users"},
+	"branding emails provider update": {"read:email_provider", "update:email_provider"},
+	"branding templates lint":         {"read:branding"},
+	"branding templates render":       {"read:branding", "read:clients", "read:custom_domains", "read:tenant_settings"},
+	"branding templates show":         {"read:branding"},
+	"branding templates update":       {"read:branding", "update:branding", "read:clients", "read:custom_domains", "read:tenant_settings"},
+	"branding texts export":           {"read:prompts", "read:tenant_settings"},
+	"branding texts import":           {"update:prompts", "read:tenant_settings"},
+	"branding texts show":             {"read:prompts", "read:tenant_settings"},
+	"branding texts update":           {"read:prompts", "update:prompts", "read:tenant_settings"},
+
+	"ips check":   {"read:anomaly_blocks"},
+	"ips unblock": {"delete:anomaly_blocks"},
+
+	"logs list":           {"read:logs"},
+	"logs show":           {"read:logs"},
+	"logs stats":          {"read:logs"},
+	"logs tail":           {"read:logs"},
+	"logs streams create": {"create:log_streams", "update:log_streams"},
+	"logs streams delete": {"read:log_streams", "delete:log_streams"},
+	"logs streams list":   {"read:log_streams"},
+	"logs streams listen": nil,
+	"logs streams open":   {"read:log_streams"},
+	"logs streams pause":  {"read:log_streams", "update:log_streams"},
+	"logs streams resume": {"read:log_streams", "update:log_streams"},
+	"logs streams show":   {"read:log_streams"},
+	"logs streams status": {"read:log_streams", "read:logs"},
+	"logs streams update": {"read:log_streams", "update:log_streams"},
+
+	"orgs create":             {"create:organizations"},
+	"orgs delete":             {"read:organizations", "delete:organizations"},
+	"orgs list":               {"read:organizations"},
+	"orgs members list":       {"read:organizations", "read:organization_members"},
+	"orgs open":               {"read:organizations"},
+	"orgs roles list":         {"read:organizations", "read:organization_members", "read:organization_member_roles"},
+	"orgs roles members list": {"read:organizations", "read:organization_members", "read:organization_member_roles"},
+	"orgs show":               {"read:organizations"},
+	"orgs update":             {"read:organizations", "update:organizations"},
+
+	"protection show":                               {"read:attack_protection"},
+	"protection blocked-ips list":                   {"read:logs", "read:anomaly_blocks"},
+	"protection blocked-ips unblock":                {"delete:anomaly_blocks"},
+	"protection breached-password-detection show":   {"read:attack_protection"},
+	"protection breached-password-detection update": {"read:attack_protection", "update:attack_protection"},
+	"protection brute-force-protection show":        {"read:attack_protection"},
+	"protection brute-force-protection update":      {"read:attack_protection", "update:attack_protection"},
+	"protection suspicious-ip-throttling show":      {"read:attack_protection"},
+	"protection suspicious-ip-throttling update":    {"read:attack_protection", "update:attack_protection"},
+
+	"quickstarts download": {"read:clients", "update:clients"},
+	"quickstarts list":     nil,
+	"quickstarts update":   nil,
+
+	"roles create":             {"create:roles"},
+	"roles delete":             {"read:roles", "delete:roles"},
+	"roles list":               {"read:roles"},
+	"roles show":               {"read:roles"},
+	"roles update":             {"read:roles", "update:roles"},
+	"roles permissions add":    {"read:roles", "update:roles", "read:resource_servers"},
+	"roles permissions list":   {"read:roles"},
+	"roles permissions remove": {"read:roles", "update:roles", "read:resource_servers"},
+
+	"rules create":  {"create:rules"},
+	"rules delete":  {"read:rules", "delete:rules"},
+	"rules disable": {"read:rules", "update:rules"},
+	"rules enable":  {"read:rules", "update:rules"},
+	"rules list":    {"read:rules"},
+	"rules show":    {"read:rules"},
+	"rules update":  {"read:rules", "update:rules"},
+
+	"tenants add":             nil,
+	"tenants doctor":          {"read:clients", "read:client_grants", "read:rules", "read:resource_servers", "read:guardian_factors", "read:custom_domains", "read:log_streams"},
+	"tenants list":            nil,
+	"tenants open":            nil,
+	"tenants settings show":   {"read:tenant_settings"},
+	"tenants settings update": {"read:tenant_settings", "update:tenant_settings"},
+	"tenants use":             nil,
+
+	"test login": {"read:clients", "create:clients", "delete:clients", "read:custom_domains"},
+	"test token": {"read:clients", "create:clients"},
+
+	"users blocks list": {"read:users"},
+	"users create":      {"create:users", "read:connections"},
+	"users delete":      {"read:users", "delete:users"},
+	"users import":      {"create:users", "read:connections"},
+	"users open":        {"read:users"},
+	"users search":      {"read:users"},
+	"users show":        {"read:users"},
+	"users unblock":     {"update:users"},
+	"users update":      {"read:users", "update:users", "read:connections"},
+
+	"completion":             nil,
+	"config init":            nil,
+	"config network clear":   nil,
+	"config network set":     nil,
+	"config network show":    nil,
+	"config secrets migrate": nil,
+	"config secrets show":    nil,
+	"login":                  nil,
+	"logout":                 nil,
+	"profiles create":        nil,
+	"profiles delete":        nil,
+	"profiles list":          nil,
+	"profiles use":           nil,
+	"signup":                 nil,
+}
+
+// scopesFor returns the scopes the command needs.
+func scopesFor(cmd *cobra.Command) []string {
+	return commandScopes[strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")]
+}
+
+// missingScopes returns the wanted scopes which weren't granted.
+func missingScopes(granted, wanted []string) []string {
+	var missing []string
+	for _, s := range wanted {
+		if !containsStr(granted, s) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// consentScopes has the user log in again to grant the missing scopes on top
+// of the ones already granted, or fails if that isn't possible.
+func (c *cli) consentScopes(ctx context.Context, t tenant, missing []string) (tenant, error) {
+	scopes := append(append([]string{}, t.Scopes...), missing...)
+
+	if c.noInput || !iostream.IsInputTerminal() || !iostream.IsOutputTerminal() {
+		return tenant{}, fmt.Errorf(
+			"This command needs scopes that weren't granted when logging in to %s: %s; run 'auth0 login --scopes %s' to grant them",
+			t.Domain, strings.Join(missing, ", "), strings.Join(withoutLoginScopes(scopes), ","),
+		)
+	}
+
+	promptText := fmt.Sprintf("This command needs scopes that weren't granted when logging in to %s: %s. Do you want to log in again to grant them?", t.Domain, strings.Join(missing, ", "))
+	if confirmed := prompt.Confirm(promptText); !confirmed {
+		return tenant{}, fmt.Errorf("Missing scopes: %s", strings.Join(missing, ", "))
+	}
+
+	return RunLogin(ctx, c, true, scopes)
+}
+
+// withoutLoginScopes drops the scopes requested on every login, to suggest
+// the --scopes to use.
+func withoutLoginScopes(scopes []string) []string {
+	var res []string
+	for _, s := range scopes {
+		if s != "openid" && s != "offline_access" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
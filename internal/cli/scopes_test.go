@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandScopes(t *testing.T) {
+	cli := &cli{}
+	root := &cobra.Command{Use: "auth0"}
+	addPersistentFlags(root, cli)
+	addSubcommands(root, cli)
+
+	declared := map[string]bool{}
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		if cmd.Runnable() {
+			path := cmd.CommandPath()[len("auth0 "):]
+			_, ok := commandScopes[path]
+			assert.True(t, ok, "the scopes of %q aren't declared", path)
+			declared[path] = true
+		}
+		for _, c := range cmd.Commands() {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	for path, scopes := range commandScopes {
+		assert.True(t, declared[path], "the scopes of %q are declared but there's no such command", path)
+
+		for _, s := range scopes {
+			assert.Contains(t, auth.RequiredScopes(), s, "%q needs a scope the CLI doesn't request", path)
+		}
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	granted := []string{"openid", "read:clients", "read:users"}
+
+	assert.Empty(t, missingScopes(granted, nil))
+	assert.Empty(t, missingScopes(granted, []string{"read:clients"}))
+	assert.Equal(t, []string{"update:users"}, missingScopes(granted, []string{"read:users", "update:users"}))
+}
+
+func TestPrepareTenantScopes(t *testing.T) {
+	cfg := config{
+		DefaultTenant: "travel0.auth0.com",
+		Tenants: map[string]tenant{
+			"travel0.auth0.com": {
+				Domain:      "travel0.auth0.com",
+				AccessToken: "token",
+				ExpiresAt:   time.Now().Add(time.Hour),
+				Scopes:      []string{"openid", "offline_access", "read:users"},
+			},
+		},
+	}
+
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, ioutil.WriteFile(path, b, 0600))
+
+	newCLI := func() *cli {
+		return &cli{path: path, noInput: true, renderer: &display.Renderer{}}
+	}
+
+	t.Run("granted scopes", func(t *testing.T) {
+		ten, err := newCLI().prepareTenant(context.Background(), []string{"read:users"})
+		require.NoError(t, err)
+		assert.Equal(t, "token", ten.AccessToken)
+	})
+
+	t.Run("missing scopes fail early", func(t *testing.T) {
+		_, err := newCLI().prepareTenant(context.Background(), []string{"read:users", "update:users"})
+		assert.EqualError(t, err, "This command needs scopes that weren't granted when logging in to travel0.auth0.com: update:users; run 'auth0 login --scopes read:users,update:users' to grant them")
+	})
+}
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth"
+	"github.com/auth0/auth0-cli/internal/iostream"
+	"github.com/auth0/auth0-cli/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+const (
+	secretsBackendKeyring = "keyring"
+	secretsBackendFile    = "file"
+
+	secretsFileName = "secrets.enc"
+)
+
+var (
+	secretsBackends = []string{secretsBackendKeyring, secretsBackendFile}
+
+	secretsBackend = Argument{
+		Name: "Backend",
+		Help: "Where to store secrets: keyring or file",
+	}
+)
+
+// secretStore returns the store the tenant credentials are kept in. It is picked,
+// in order, from:
+//
+// 1. the AUTH0_SECRETS_BACKEND env var
+// 2. the secrets_backend of the config file
+// 3. the OS keyring if it's available, or else an encrypted file.
+func (c *cli) secretStore() auth.SecretStore {
+	if c.secrets == nil {
+		c.secrets = c.secretStoreFor(c.secretsBackend())
+	}
+	return c.secrets
+}
+
+// secretsBackend resolves which backend is in use.
+func (c *cli) secretsBackend() string {
+	backend := os.Getenv("AUTH0_SECRETS_BACKEND")
+	if backend == "" {
+		_ = c.init()
+		backend = c.config.SecretsBackend
+	}
+
+	switch backend {
+	case secretsBackendKeyring, secretsBackendFile:
+		return backend
+	default:
+		if auth.KeyringAvailable() {
+			return secretsBackendKeyring
+		}
+		return secretsBackendFile
+	}
+}
+
+func (c *cli) secretStoreFor(backend string) auth.SecretStore {
+	if backend == secretsBackendFile {
+		return &auth.FileSecretStore{
+			Path:       c.secretsFilePath(),
+			Passphrase: c.secretsPassphrase,
+		}
+	}
+	return &auth.Keyring{}
+}
+
+func (c *cli) secretsFilePath() string {
+	if c.path == "" {
+		return filepath.Join(filepath.Dir(defaultConfigPath()), secretsFileName)
+	}
+	return filepath.Join(filepath.Dir(c.path), secretsFileName)
+}
+
+// secretsPassphrase reads the passphrase of the secrets file from the
+// AUTH0_SECRETS_PASSPHRASE env var, prompting for it otherwise.
+func (c *cli) secretsPassphrase() (string, error) {
+	if p := os.Getenv("AUTH0_SECRETS_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+
+	if c.noInput || !iostream.IsInputTerminal() || !iostream.IsOutputTerminal() {
+		return "", errors.New("The OS keyring is unavailable and secrets are stored in an encrypted file: set AUTH0_SECRETS_PASSPHRASE to its passphrase")
+	}
+
+	if _, err := os.Stat(c.secretsFilePath()); err == nil {
+		var passphrase string
+		if err := prompt.AskOne(prompt.PasswordInput("passphrase", "Passphrase of the secrets file:", "", true), &passphrase); err != nil {
+			return "", err
+		}
+		return passphrase, nil
+	}
+
+	// The file is about to be created, so make sure there's no typo in the
+	// passphrase that would lock the secrets away.
+	var answers struct {
+		Passphrase   string
+		Confirmation string
+	}
+	if err := prompt.Ask([]*survey.Question{
+		prompt.PasswordInput("passphrase", "Choose a passphrase to encrypt the secrets file:", "", true),
+		prompt.PasswordInput("confirmation", "Confirm the passphrase:", "", true),
+	}, &answers); err != nil {
+		return "", err
+	}
+	if answers.Passphrase != answers.Confirmation {
+		return "", errors.New("The passphrases don't match")
+	}
+
+	return answers.Passphrase, nil
+}
+
+func secretsCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage where the CLI stores secrets",
+		Long: `Manage where the CLI stores secrets, e.g. refresh tokens.
+
+Secrets are kept in the OS keyring when it's available, or else in a file
+encrypted with a passphrase, which is read from AUTH0_SECRETS_PASSPHRASE or
+prompted for. Set AUTH0_SECRETS_BACKEND to keyring or file to override the
+configured backend.`,
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showSecretsCmd(cli))
+	cmd.AddCommand(migrateSecretsCmd(cli))
+	return cmd
+}
+
+func showSecretsCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show",
+		Args:    cobra.NoArgs,
+		Short:   "Show where secrets are stored",
+		Long:    "Show where secrets are stored.",
+		Example: "auth0 config secrets show",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backend := cli.secretsBackend()
+
+			switch backend {
+			case secretsBackendFile:
+				cli.renderer.Infof("Secrets are stored in the encrypted file %s", cli.secretsFilePath())
+			default:
+				cli.renderer.Infof("Secrets are stored in the OS keyring")
+			}
+
+			if cli.config.SecretsBackend == "" && os.Getenv("AUTH0_SECRETS_BACKEND") == "" {
+				cli.renderer.Infof("%s", ansi.Faint("No backend is configured, so the keyring is used whenever it's available."))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func migrateSecretsCmd(cli *cli) *cobra.Command {
+	var inputs struct {
+		Backend string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Move the stored secrets to another backend",
+		Long: `Move the refresh tokens and credentials of every tenant to another backend,
+and use that backend from now on.`,
+		Example: `auth0 config secrets migrate file
+auth0 config secrets migrate keyring`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				if err := secretsBackend.Pick(cmd, &inputs.Backend, secretsBackendPickerOptions); err != nil {
+					return err
+				}
+			} else {
+				inputs.Backend = args[0]
+			}
+
+			if !containsStr(secretsBackends, inputs.Backend) {
+				return fmt.Errorf("Invalid backend %q, use one of: keyring, file", inputs.Backend)
+			}
+
+			if err := cli.init(); err != nil {
+				return err
+			}
+
+			from := cli.secretsBackend()
+			if from == inputs.Backend {
+				cli.renderer.Infof("Secrets are already stored in the %s backend", from)
+				return cli.setSecretsBackend(inputs.Backend)
+			}
+
+			if inputs.Backend == secretsBackendKeyring && !auth.KeyringAvailable() {
+				return errors.New("The OS keyring isn't available on this machine")
+			}
+
+			var keys []string
+			for _, t := range cli.config.Tenants {
+				keys = append(keys, t.secretKeys()...)
+			}
+
+			// No spinner here, as the file backend may prompt for its
+			// passphrase.
+			migrated, err := migrateSecrets(cli.secretStoreFor(from), cli.secretStoreFor(inputs.Backend), keys)
+			if err != nil {
+				return fmt.Errorf("Unable to migrate the secrets: %w", err)
+			}
+
+			if err := cli.setSecretsBackend(inputs.Backend); err != nil {
+				return err
+			}
+
+			cli.renderer.Infof("Moved %d secret(s) from the %s to the %s backend", migrated, from, inputs.Backend)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// migrateSecrets copies the given secrets from one store to another, and only
+// deletes them from the former once they're all copied.
+func migrateSecrets(from, to auth.SecretStore, keys []string) (int, error) {
+	var migrated []string
+	for _, key := range keys {
+		v, err := from.Get(auth.SecretsNamespace, key)
+		if errors.Is(err, auth.ErrSecretNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("unable to read the secret of %s: %w", key, err)
+		}
+
+		if err := to.Set(auth.SecretsNamespace, key, v); err != nil {
+			return 0, fmt.Errorf("unable to write the secret of %s: %w", key, err)
+		}
+		migrated = append(migrated, key)
+	}
+
+	for _, key := range migrated {
+		if err := from.Delete(auth.SecretsNamespace, key); err != nil {
+			return 0, fmt.Errorf("unable to delete the secret of %s: %w", key, err)
+		}
+	}
+
+	return len(migrated), nil
+}
+
+func (c *cli) setSecretsBackend(backend string) error {
+	c.config.SecretsBackend = backend
+	c.secrets = nil
+
+	if err := c.persistConfig(); err != nil {
+		return fmt.Errorf("Unexpected error persisting config: %w", err)
+	}
+	return nil
+}
+
+func secretsBackendPickerOptions() (pickerOptions, error) {
+	return pickerOptions{
+		{label: "OS keyring", value: secretsBackendKeyring},
+		{label: "Encrypted file", value: secretsBackendFile},
+	}, nil
+}
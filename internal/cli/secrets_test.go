@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/auth0/auth0-cli/internal/auth"
+	"github.com/auth0/auth0-cli/internal/auth/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateSecrets(t *testing.T) {
+	t.Run("moves the secrets found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		from := mock.NewMockSecretStore(ctrl)
+		to := mock.NewMockSecretStore(ctrl)
+
+		from.EXPECT().Get(auth.SecretsNamespace, "a.auth0.com").Return("token-a", nil)
+		from.EXPECT().Get(auth.SecretsNamespace, "b.auth0.com").Return("", auth.ErrSecretNotFound)
+		to.EXPECT().Set(auth.SecretsNamespace, "a.auth0.com", "token-a").Return(nil)
+		from.EXPECT().Delete(auth.SecretsNamespace, "a.auth0.com").Return(nil)
+
+		n, err := migrateSecrets(from, to, []string{"a.auth0.com", "b.auth0.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("keeps the secrets when a write fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		from := mock.NewMockSecretStore(ctrl)
+		to := mock.NewMockSecretStore(ctrl)
+
+		from.EXPECT().Get(auth.SecretsNamespace, "a.auth0.com").Return("token-a", nil)
+		from.EXPECT().Get(auth.SecretsNamespace, "b.auth0.com").Return("token-b", nil)
+		to.EXPECT().Set(auth.SecretsNamespace, "a.auth0.com", "token-a").Return(nil)
+		to.EXPECT().Set(auth.SecretsNamespace, "b.auth0.com", "token-b").Return(errors.New("wrong passphrase"))
+
+		_, err := migrateSecrets(from, to, []string{"a.auth0.com", "b.auth0.com"})
+
+		assert.EqualError(t, err, "unable to write the secret of b.auth0.com: wrong passphrase")
+	})
+}
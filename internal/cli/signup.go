@@ -20,7 +20,7 @@ func signupCmd(cli *cli) *cobra.Command {
 		Long:  "If you dont alredy have an Auth0 account, you can signup for one using the CLI!",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			_, err := RunLogin(ctx, cli, false)
+			_, err := RunLogin(ctx, cli, false, nil)
 			if err == nil {
 				cli.tracker.TrackCommandRun(cmd, cli.config.InstallID)
 			}
@@ -46,7 +46,7 @@ func RunSignup(ctx context.Context, cli *cli, expired bool) (tenant, error) {
 		fmt.Print("If you don't have an account, please go to https://auth0.com/signup\n\n")
 	}
 
-	state, err := cli.authenticator.Start(ctx)
+	state, err := cli.authenticator.Start(ctx, nil)
 	if err != nil {
 		return tenant{}, fmt.Errorf("Could not start the authentication process: %w.", err)
 	}
@@ -80,7 +80,7 @@ func RunSignup(ctx context.Context, cli *cli, expired bool) (tenant, error) {
 	cli.renderer.Infof("Tenant: %s\n", res.Domain)
 
 	// store the refresh token
-	secretsStore := &auth.Keyring{}
+	secretsStore := cli.secretStore()
 	err = secretsStore.Set(auth.SecretsNamespace, res.Domain, res.RefreshToken)
 	if err != nil {
 		// log the error but move on
@@ -0,0 +1,652 @@
+package cli
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+)
+
+const (
+	legacyGrantTypePrefix = "http://auth0.com/oauth/legacy/grant-type/"
+
+	// certificateExpiryWarning is how close to its expiry a custom domain
+	// certificate has to be to be reported.
+	certificateExpiryWarning = 14 * 24 * time.Hour
+)
+
+var (
+	ruleComments = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	ruleNoop     = regexp.MustCompile(`^function\s*\w*\s*\(\s*(\w+)\s*,\s*(\w+)\s*,\s*(\w+)\s*\)\s*\{\s*(?:return\s+)?(\w+)\s*\(\s*null\s*,\s*(\w+)\s*,\s*(\w+)\s*\)\s*;?\s*\}$`)
+	ruleClients  = regexp.MustCompile(`context\.(?:clientID|clientName)\s*===?\s*['"]([^'"]+)['"]|['"]([^'"]+)['"]\s*===?\s*context\.(?:clientID|clientName)`)
+	ruleNegation = regexp.MustCompile(`context\.(?:clientID|clientName)\s*!==?|!==?\s*context\.(?:clientID|clientName)`)
+)
+
+// tenantDoctorData is everything the doctor checks look at, fetched upfront
+// so the checks themselves don't have to talk to the API.
+type tenantDoctorData struct {
+	clients    []*management.Client
+	clientsErr error
+
+	rules    []*management.Rule
+	rulesErr error
+
+	apis    []*management.ResourceServer
+	apisErr error
+
+	grants    []*management.ClientGrant
+	grantsErr error
+
+	factors    []*management.MultiFactor
+	factorsErr error
+
+	domains    []*management.CustomDomain
+	domainsErr error
+
+	// certificates holds the expiry date of the certificate served by every
+	// ready custom domain, or the error when it couldn't be retrieved.
+	certificates     map[string]time.Time
+	certificatesErrs map[string]error
+
+	streams    []*management.LogStream
+	streamsErr error
+}
+
+type tenantDoctorCheck struct {
+	name string
+	err  func(d *tenantDoctorData) error
+	run  func(d *tenantDoctorData, link func(string) string, now time.Time) []display.DoctorFinding
+}
+
+var tenantDoctorChecks = []tenantDoctorCheck{
+	{
+		name: "Application callbacks",
+		err:  func(d *tenantDoctorData) error { return d.clientsErr },
+		run:  checkClientCallbacks,
+	},
+	{
+		name: "Single page application secrets",
+		err:  func(d *tenantDoctorData) error { return d.clientsErr },
+		run:  checkSPAAuthMethod,
+	},
+	{
+		name: "Legacy grant types",
+		err:  func(d *tenantDoctorData) error { return d.clientsErr },
+		run:  checkLegacyGrants,
+	},
+	{
+		name: "Multi-factor authentication",
+		err:  func(d *tenantDoctorData) error { return d.factorsErr },
+		run:  checkMFA,
+	},
+	{
+		name: "Unused rules",
+		err:  func(d *tenantDoctorData) error { return firstError(d.rulesErr, d.clientsErr) },
+		run:  checkUnusedRules,
+	},
+	{
+		name: "API consent",
+		err:  func(d *tenantDoctorData) error { return firstError(d.apisErr, d.clientsErr, d.grantsErr) },
+		run:  checkSkipConsent,
+	},
+	{
+		name: "Custom domain certificates",
+		err:  func(d *tenantDoctorData) error { return d.domainsErr },
+		run:  checkCustomDomains,
+	},
+	{
+		name: "Log streams",
+		err:  func(d *tenantDoctorData) error { return d.streamsErr },
+		run:  checkLogStreams,
+	},
+}
+
+func tenantDoctorCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Args:  cobra.NoArgs,
+		Short: "Check your tenant for common misconfigurations",
+		Long: `Check your tenant for common misconfigurations: insecure or wildcard
+callbacks, single page applications using a client secret, legacy grant types,
+MFA not being enabled, unused rules, APIs skipping consent while third party
+applications are granted access to them, expiring custom domain certificates
+and failing log streams.
+
+Findings are listed by priority, each with a link to where it can be fixed.`,
+		Example: `auth0 tenants doctor
+auth0 tenants doctor --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data *tenantDoctorData
+
+			if err := ansi.Spinner("Checking the tenant", func() error {
+				data = cli.tenantDoctorData()
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			cli.renderer.TenantDoctor(runTenantDoctorChecks(data, formatManageTenantURL(cli.tenant, cli.config), time.Now()))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func (c *cli) tenantDoctorData() *tenantDoctorData {
+	d := &tenantDoctorData{}
+
+	d.clients, d.clientsErr = c.doctorClients()
+	d.rules, d.rulesErr = c.doctorRules()
+	d.apis, d.apisErr = c.doctorAPIs()
+	d.grants, d.grantsErr = c.doctorClientGrants()
+	d.factors, d.factorsErr = c.api.MultiFactor.List()
+	d.streams, d.streamsErr = c.api.LogStream.List()
+
+	d.domains, d.domainsErr = c.api.CustomDomain.List()
+	d.certificates, d.certificatesErrs = certificateExpiries(c.tlsTransport(), d.domains)
+
+	return d
+}
+
+func (c *cli) doctorClients() ([]*management.Client, error) {
+	var clients []*management.Client
+	for page := 0; ; page++ {
+		list, err := c.api.Client.List(
+			management.Parameter("is_global", "false"),
+			management.Page(page),
+			management.PerPage(100),
+		)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, list.Clients...)
+		if !list.HasNext() {
+			return clients, nil
+		}
+	}
+}
+
+func (c *cli) doctorRules() ([]*management.Rule, error) {
+	var rules []*management.Rule
+	for page := 0; ; page++ {
+		list, err := c.api.Rule.List(management.Page(page), management.PerPage(100))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, list.Rules...)
+		if !list.HasNext() {
+			return rules, nil
+		}
+	}
+}
+
+func (c *cli) doctorAPIs() ([]*management.ResourceServer, error) {
+	var apis []*management.ResourceServer
+	for page := 0; ; page++ {
+		list, err := c.api.ResourceServer.List(management.Page(page), management.PerPage(100))
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, list.ResourceServers...)
+		if !list.HasNext() {
+			return apis, nil
+		}
+	}
+}
+
+func (c *cli) doctorClientGrants() ([]*management.ClientGrant, error) {
+	var grants []*management.ClientGrant
+	for page := 0; ; page++ {
+		list, err := c.api.ClientGrant.List(management.Page(page), management.PerPage(100))
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, list.ClientGrants...)
+		if !list.HasNext() {
+			return grants, nil
+		}
+	}
+}
+
+// certificateExpiries retrieves the certificate served by every ready custom
+// domain. The management API doesn't expose it, so it's read from the TLS
+// handshake of a request through base instead, which honors the proxy.
+func certificateExpiries(base *http.Transport, domains []*management.CustomDomain) (map[string]time.Time, map[string]error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		expiries = make(map[string]time.Time)
+		errs     = make(map[string]error)
+	)
+
+	for _, d := range domains {
+		if d.GetStatus() != "ready" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(domain string) {
+			defer wg.Done()
+
+			expiry, err := certificateExpiry(base, domain)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[domain] = err
+				return
+			}
+			expiries[domain] = expiry
+		}(d.GetDomain())
+	}
+
+	wg.Wait()
+
+	return expiries, errs
+}
+
+// systemCertPool returns the certificate authorities which custom domain
+// certificates are issued by.
+var systemCertPool = x509.SystemCertPool
+
+var errCertificateBehindProxy = errors.New("it couldn't be checked through the proxy, which serves a certificate of its own")
+
+func certificateExpiry(base *http.Transport, domain string) (time.Time, error) {
+	// The chain is verified once the certificates are read rather than
+	// during the handshake, so that expired certificates can be reported.
+	t := base.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	roots := t.TLSClientConfig.RootCAs
+	t.TLSClientConfig.InsecureSkipVerify = true // nolint:gosec
+
+	client := &http.Client{
+		Transport: t,
+		Timeout:   10 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer t.CloseIdleConnections()
+
+	req, err := http.NewRequest(http.MethodHead, "https://"+domain, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var proxied bool
+	if t.Proxy != nil {
+		u, err := t.Proxy(req)
+		proxied = err == nil && u != nil
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	res.Body.Close()
+
+	if res.TLS == nil || len(res.TLS.PeerCertificates) == 0 {
+		return time.Time{}, fmt.Errorf("no certificate served")
+	}
+	certs := res.TLS.PeerCertificates
+
+	host := domain
+	if h, _, err := net.SplitHostPort(domain); err == nil {
+		host = h
+	}
+
+	if err := certs[0].VerifyHostname(host); err != nil {
+		if proxied {
+			return time.Time{}, errCertificateBehindProxy
+		}
+		return time.Time{}, fmt.Errorf("the certificate served isn't issued for %s", host)
+	}
+
+	system, err := systemCertPool()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to load the system certificate authorities: %w", err)
+	}
+
+	err = verifyCertificateChain(certs, host, system)
+	if err == nil {
+		return certs[0].NotAfter, nil
+	}
+
+	// Trusted thanks to the --ca-file only, i.e. re-signed by a proxy
+	// inspecting the TLS traffic.
+	if roots != nil && verifyCertificateChain(certs, host, roots) == nil {
+		return time.Time{}, errCertificateBehindProxy
+	}
+
+	return time.Time{}, fmt.Errorf("the certificate served isn't trusted: %w", err)
+}
+
+// verifyCertificateChain verifies the certificates served for a host, as of
+// the expiry of the leaf when it's past.
+func verifyCertificateChain(certs []*x509.Certificate, host string, roots *x509.CertPool) error {
+	opts := x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   time.Now(),
+	}
+	for _, c := range certs[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	if opts.CurrentTime.After(certs[0].NotAfter) {
+		opts.CurrentTime = certs[0].NotAfter
+	}
+
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+func runTenantDoctorChecks(d *tenantDoctorData, manageURL string, now time.Time) []display.DoctorCheck {
+	link := func(path string) string {
+		if manageURL == "" || path == "" {
+			return ""
+		}
+		return manageURL + path
+	}
+
+	var checks []display.DoctorCheck
+	for _, c := range tenantDoctorChecks {
+		check := display.DoctorCheck{Name: c.name, Findings: []display.DoctorFinding{}}
+
+		if err := c.err(d); err != nil {
+			check.Error = err.Error()
+		} else {
+			for _, f := range c.run(d, link, now) {
+				f.Check = c.name
+				check.Findings = append(check.Findings, f)
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func checkClientCallbacks(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	var findings []display.DoctorFinding
+	for _, c := range d.clients {
+		for _, cb := range interfaceToStringSlice(c.Callbacks) {
+			switch {
+			case strings.HasPrefix(cb, "http://") && !isLocalURL(cb):
+				findings = append(findings, display.DoctorFinding{
+					Priority: display.DoctorPriorityHigh,
+					Problem:  fmt.Sprintf("Application %s allows the insecure callback %s", c.GetName(), cb),
+					URL:      link(formatAppSettingsPath(c.GetClientID())),
+				})
+			case strings.Contains(cb, "*"):
+				findings = append(findings, display.DoctorFinding{
+					Priority: display.DoctorPriorityMedium,
+					Problem:  fmt.Sprintf("Application %s allows the wildcard callback %s", c.GetName(), cb),
+					URL:      link(formatAppSettingsPath(c.GetClientID())),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func isLocalURL(u string) bool {
+	host := strings.TrimPrefix(u, "http://")
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host == "localhost" || host == "127.0.0.1" || host == "[::1]" || host == "::1"
+}
+
+func checkSPAAuthMethod(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	var findings []display.DoctorFinding
+	for _, c := range d.clients {
+		if c.GetAppType() != appTypeSPA || c.GetTokenEndpointAuthMethod() == "none" {
+			continue
+		}
+		findings = append(findings, display.DoctorFinding{
+			Priority: display.DoctorPriorityHigh,
+			Problem:  fmt.Sprintf("Single page application %s authenticates with a client secret (%s)", c.GetName(), c.GetTokenEndpointAuthMethod()),
+			URL:      link(formatAppSettingsPath(c.GetClientID())),
+		})
+	}
+	return findings
+}
+
+func checkLegacyGrants(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	var findings []display.DoctorFinding
+	for _, c := range d.clients {
+		var legacy []string
+		for _, g := range interfaceToStringSlice(c.GrantTypes) {
+			if strings.HasPrefix(g, legacyGrantTypePrefix) {
+				legacy = append(legacy, strings.TrimPrefix(g, legacyGrantTypePrefix))
+			}
+		}
+		if len(legacy) == 0 {
+			continue
+		}
+		findings = append(findings, display.DoctorFinding{
+			Priority: display.DoctorPriorityMedium,
+			Problem:  fmt.Sprintf("Application %s uses legacy grant types: %s", c.GetName(), strings.Join(legacy, ", ")),
+			URL:      link(formatAppSettingsPath(c.GetClientID())),
+		})
+	}
+	return findings
+}
+
+func checkMFA(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	for _, f := range d.factors {
+		if f.GetEnabled() {
+			return nil
+		}
+	}
+	return []display.DoctorFinding{{
+		Priority: display.DoctorPriorityHigh,
+		Problem:  "No multi-factor authentication factor is enabled",
+		URL:      link("security/mfa"),
+	}}
+}
+
+func checkUnusedRules(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	clients := make(map[string]bool)
+	for _, c := range d.clients {
+		clients[c.GetClientID()] = true
+		clients[c.GetName()] = true
+	}
+
+	var findings []display.DoctorFinding
+	for _, r := range d.rules {
+		if !r.GetEnabled() {
+			continue
+		}
+
+		var problem string
+		switch {
+		case isNoopRule(r.GetScript()):
+			problem = fmt.Sprintf("Rule %s is enabled but does nothing", r.GetName())
+		case onlyReferencesMissingClients(r.GetScript(), clients):
+			problem = fmt.Sprintf("Rule %s is enabled but only applies to applications that no longer exist", r.GetName())
+		default:
+			continue
+		}
+
+		findings = append(findings, display.DoctorFinding{
+			Priority: display.DoctorPriorityLow,
+			Problem:  problem,
+			URL:      link("rules/" + r.GetID()),
+		})
+	}
+	return findings
+}
+
+// isNoopRule reports whether a rule script only hands the user and context
+// it receives back to its callback, e.g. the body of the empty rule template.
+func isNoopRule(script string) bool {
+	script = strings.TrimSpace(ruleComments.ReplaceAllString(script, ""))
+
+	m := ruleNoop.FindStringSubmatch(script)
+	if m == nil {
+		return false
+	}
+
+	user, context, callback := m[1], m[2], m[3]
+	return m[4] == callback && m[5] == user && m[6] == context
+}
+
+// onlyReferencesMissingClients reports whether a rule script only runs for
+// clients, matched by ID or name, that aren't in the tenant anymore. Rules
+// with negated comparisons run for every other client, so they're skipped.
+func onlyReferencesMissingClients(script string, clients map[string]bool) bool {
+	script = ruleComments.ReplaceAllString(script, "")
+
+	if ruleNegation.MatchString(script) {
+		return false
+	}
+
+	matches := ruleClients.FindAllStringSubmatch(script, -1)
+	if len(matches) == 0 {
+		return false
+	}
+
+	for _, m := range matches {
+		ref := m[1]
+		if ref == "" {
+			ref = m[2]
+		}
+		if clients[ref] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkSkipConsent reports the APIs skipping consent that third party
+// applications are granted access to, rather than every API skipping consent
+// while some third party application exists.
+func checkSkipConsent(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	thirdParty := map[string]string{}
+	for _, c := range d.clients {
+		if c.IsFirstParty != nil && !c.GetIsFirstParty() {
+			thirdParty[c.GetClientID()] = c.GetName()
+		}
+	}
+
+	granted := map[string][]string{}
+	for _, g := range d.grants {
+		if name, ok := thirdParty[g.GetClientID()]; ok {
+			granted[g.GetAudience()] = append(granted[g.GetAudience()], name)
+		}
+	}
+
+	var findings []display.DoctorFinding
+	for _, api := range d.apis {
+		names := granted[api.GetIdentifier()]
+		if !api.GetSkipConsentForVerifiableFirstPartyClients() || len(names) == 0 {
+			continue
+		}
+		findings = append(findings, display.DoctorFinding{
+			Priority: display.DoctorPriorityMedium,
+			Problem: fmt.Sprintf(
+				"API %s skips user consent while third party applications are granted access to it (%s)",
+				api.GetName(),
+				strings.Join(names, ", "),
+			),
+			URL: link(formatApiSettingsPath(api.GetID())),
+		})
+	}
+	return findings
+}
+
+func checkCustomDomains(d *tenantDoctorData, link func(string) string, now time.Time) []display.DoctorFinding {
+	var findings []display.DoctorFinding
+	for _, cd := range d.domains {
+		domain := cd.GetDomain()
+
+		if cd.GetStatus() != "ready" {
+			findings = append(findings, display.DoctorFinding{
+				Priority: display.DoctorPriorityLow,
+				Problem:  fmt.Sprintf("Custom domain %s isn't ready (%s)", domain, cd.GetStatus()),
+				URL:      link("tenant/custom_domains"),
+			})
+			continue
+		}
+
+		if err, ok := d.certificatesErrs[domain]; ok {
+			findings = append(findings, display.DoctorFinding{
+				Priority: display.DoctorPriorityMedium,
+				Problem:  fmt.Sprintf("Unable to check the certificate of custom domain %s: %s", domain, err),
+				URL:      link("tenant/custom_domains"),
+			})
+			continue
+		}
+
+		expiry, ok := d.certificates[domain]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case !expiry.After(now):
+			findings = append(findings, display.DoctorFinding{
+				Priority: display.DoctorPriorityHigh,
+				Problem:  fmt.Sprintf("The certificate of custom domain %s expired on %s", domain, expiry.Format("2006-01-02")),
+				URL:      link("tenant/custom_domains"),
+			})
+		case expiry.Sub(now) < certificateExpiryWarning:
+			findings = append(findings, display.DoctorFinding{
+				Priority: display.DoctorPriorityMedium,
+				Problem:  fmt.Sprintf("The certificate of custom domain %s expires on %s", domain, expiry.Format("2006-01-02")),
+				URL:      link("tenant/custom_domains"),
+			})
+		}
+	}
+	return findings
+}
+
+func checkLogStreams(d *tenantDoctorData, link func(string) string, _ time.Time) []display.DoctorFinding {
+	var findings []display.DoctorFinding
+	for _, ls := range d.streams {
+		var priority string
+		switch ls.GetStatus() {
+		case "suspended":
+			priority = display.DoctorPriorityHigh
+		case "paused":
+			priority = display.DoctorPriorityLow
+		default:
+			continue
+		}
+		findings = append(findings, display.DoctorFinding{
+			Priority: priority,
+			Problem:  fmt.Sprintf("Log stream %s is %s", ls.GetName(), ls.GetStatus()),
+			URL:      link(formatLogStreamSettingsPath(ls.GetID())),
+		})
+	}
+	return findings
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
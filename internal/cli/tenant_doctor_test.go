@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckClientCallbacks(t *testing.T) {
+	d := &tenantDoctorData{
+		clients: []*management.Client{
+			{
+				ClientID: auth0.String("app-1"),
+				Name:     auth0.String("Insecure"),
+				Callbacks: []interface{}{
+					"http://example.com/callback",
+					"http://localhost:3000/callback",
+					"http://127.0.0.1/callback",
+					"https://*.example.com/callback",
+					"https://example.com/callback",
+				},
+			},
+		},
+	}
+
+	findings := checkClientCallbacks(d, func(path string) string { return "https://manage/" + path }, time.Now())
+
+	assert.Equal(t, []display.DoctorFinding{
+		{
+			Priority: display.DoctorPriorityHigh,
+			Problem:  "Application Insecure allows the insecure callback http://example.com/callback",
+			URL:      "https://manage/applications/app-1/settings",
+		},
+		{
+			Priority: display.DoctorPriorityMedium,
+			Problem:  "Application Insecure allows the wildcard callback https://*.example.com/callback",
+			URL:      "https://manage/applications/app-1/settings",
+		},
+	}, findings)
+}
+
+func TestCheckSPAAuthMethod(t *testing.T) {
+	d := &tenantDoctorData{
+		clients: []*management.Client{
+			{Name: auth0.String("Public"), AppType: auth0.String("spa"), TokenEndpointAuthMethod: auth0.String("none")},
+			{Name: auth0.String("Secret"), AppType: auth0.String("spa"), TokenEndpointAuthMethod: auth0.String("client_secret_post")},
+			{Name: auth0.String("Web"), AppType: auth0.String("regular_web"), TokenEndpointAuthMethod: auth0.String("client_secret_post")},
+		},
+	}
+
+	findings := checkSPAAuthMethod(d, noLink, time.Now())
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, display.DoctorPriorityHigh, findings[0].Priority)
+	assert.Contains(t, findings[0].Problem, "Secret")
+}
+
+func TestCheckLegacyGrants(t *testing.T) {
+	d := &tenantDoctorData{
+		clients: []*management.Client{
+			{Name: auth0.String("Modern"), GrantTypes: []interface{}{"authorization_code"}},
+			{Name: auth0.String("Legacy"), GrantTypes: []interface{}{"implicit", legacyGrantTypePrefix + "access_token"}},
+		},
+	}
+
+	findings := checkLegacyGrants(d, noLink, time.Now())
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "Application Legacy uses legacy grant types: access_token", findings[0].Problem)
+}
+
+func TestCheckMFA(t *testing.T) {
+	t.Run("no factor enabled", func(t *testing.T) {
+		d := &tenantDoctorData{factors: []*management.MultiFactor{{Name: auth0.String("sms"), Enabled: auth0.Bool(false)}}}
+		assert.Len(t, checkMFA(d, noLink, time.Now()), 1)
+	})
+
+	t.Run("a factor enabled", func(t *testing.T) {
+		d := &tenantDoctorData{factors: []*management.MultiFactor{
+			{Name: auth0.String("sms"), Enabled: auth0.Bool(false)},
+			{Name: auth0.String("otp"), Enabled: auth0.Bool(true)},
+		}}
+		assert.Empty(t, checkMFA(d, noLink, time.Now()))
+	})
+}
+
+func TestIsNoopRule(t *testing.T) {
+	tests := []struct {
+		script string
+		want   bool
+	}{
+		{"function (user, context, callback) {\n  callback(null, user, context);\n}", true},
+		{"function rule(u, ctx, cb) {\n  // TODO: implement\n  return cb(null, u, ctx);\n}", true},
+		{"function (user, context, callback) {\n  /* nothing yet */\n  callback(null, user, context)\n}", true},
+		{"function (user, context, callback) {\n  callback(null, context, user);\n}", false},
+		{"function (user, context, callback) {\n  user.app_metadata = {};\n  callback(null, user, context);\n}", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, isNoopRule(test.script), test.script)
+	}
+}
+
+func TestOnlyReferencesMissingClients(t *testing.T) {
+	clients := map[string]bool{"app-1": true, "My App": true}
+
+	tests := []struct {
+		name   string
+		script string
+		want   bool
+	}{
+		{"missing client ID", `if (context.clientID === "gone") {}`, true},
+		{"missing client name", `if ('Old App' == context.clientName) {}`, true},
+		{"existing client ID", `if (context.clientID === "app-1") {}`, false},
+		{"existing client name", `if (context.clientName === 'My App' || context.clientID === 'gone') {}`, false},
+		{"negation", `if (context.clientID !== "gone") { return callback(null, user, context); }`, false},
+		{"no reference", `user.app_metadata = {};`, false},
+		{"commented reference", `// if (context.clientID === "gone")`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, onlyReferencesMissingClients(test.script, clients))
+		})
+	}
+}
+
+func TestCheckUnusedRules(t *testing.T) {
+	d := &tenantDoctorData{
+		rules: []*management.Rule{
+			{ID: auth0.String("rul_1"), Name: auth0.String("noop"), Enabled: auth0.Bool(true), Script: auth0.String("function (u, c, cb) { cb(null, u, c); }")},
+			{ID: auth0.String("rul_2"), Name: auth0.String("disabled"), Enabled: auth0.Bool(false), Script: auth0.String("function (u, c, cb) { cb(null, u, c); }")},
+			{ID: auth0.String("rul_3"), Name: auth0.String("orphan"), Enabled: auth0.Bool(true), Script: auth0.String(`function (u, c, cb) { if (c.clientID === "x") {} cb(null, u, c); }`)},
+			{ID: auth0.String("rul_4"), Name: auth0.String("stale"), Enabled: auth0.Bool(true), Script: auth0.String(`function (u, context, cb) { if (context.clientID === "gone") {} cb(null, u, context); }`)},
+		},
+	}
+
+	findings := checkUnusedRules(d, func(path string) string { return path }, time.Now())
+
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "Rule noop is enabled but does nothing", findings[0].Problem)
+	assert.Equal(t, "rules/rul_1", findings[0].URL)
+	assert.Equal(t, "Rule stale is enabled but only applies to applications that no longer exist", findings[1].Problem)
+}
+
+func TestCheckSkipConsent(t *testing.T) {
+	apis := []*management.ResourceServer{
+		{ID: auth0.String("api-1"), Name: auth0.String("Skips"), Identifier: auth0.String("https://skips"), SkipConsentForVerifiableFirstPartyClients: auth0.Bool(true)},
+		{ID: auth0.String("api-2"), Name: auth0.String("Asks"), Identifier: auth0.String("https://asks"), SkipConsentForVerifiableFirstPartyClients: auth0.Bool(false)},
+		{ID: auth0.String("api-3"), Name: auth0.String("Ungranted"), Identifier: auth0.String("https://ungranted"), SkipConsentForVerifiableFirstPartyClients: auth0.Bool(true)},
+	}
+	clients := []*management.Client{
+		{ClientID: auth0.String("first"), Name: auth0.String("First"), IsFirstParty: auth0.Bool(true)},
+		{ClientID: auth0.String("third"), Name: auth0.String("Third"), IsFirstParty: auth0.Bool(false)},
+	}
+
+	t.Run("with third party applications granted access", func(t *testing.T) {
+		d := &tenantDoctorData{
+			apis:    apis,
+			clients: clients,
+			grants: []*management.ClientGrant{
+				{ClientID: auth0.String("third"), Audience: auth0.String("https://skips")},
+				{ClientID: auth0.String("third"), Audience: auth0.String("https://asks")},
+				{ClientID: auth0.String("first"), Audience: auth0.String("https://ungranted")},
+			},
+		}
+
+		findings := checkSkipConsent(d, noLink, time.Now())
+
+		assert.Len(t, findings, 1)
+		assert.Equal(t, "API Skips skips user consent while third party applications are granted access to it (Third)", findings[0].Problem)
+	})
+
+	t.Run("without grants to third party applications", func(t *testing.T) {
+		d := &tenantDoctorData{
+			apis:    apis,
+			clients: clients,
+			grants:  []*management.ClientGrant{{ClientID: auth0.String("first"), Audience: auth0.String("https://skips")}},
+		}
+
+		assert.Empty(t, checkSkipConsent(d, noLink, time.Now()))
+	})
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	// A proxy tunneling the CONNECT requests to the server.
+	tunneled := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		tunneled <- r.Host
+
+		upstream, err := net.Dial("tcp", r.Host)
+		if !assert.NoError(t, err) {
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		go func() {
+			_, _ = io.Copy(upstream, conn)
+			upstream.Close()
+		}()
+		_, _ = io.Copy(conn, upstream)
+		conn.Close()
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NoError(t, err)
+	proxied := &http.Transport{Proxy: http.ProxyURL(proxyURL)}
+
+	// The test server stands for the public certificate authorities, or for
+	// a proxy inspecting the TLS traffic when only trusted by the --ca-file.
+	trusted := x509.NewCertPool()
+	trusted.AddCert(srv.Certificate())
+	withSystemPool := func(t *testing.T, pool *x509.CertPool) {
+		t.Helper()
+		systemCertPool = func() (*x509.CertPool, error) { return pool, nil }
+		t.Cleanup(func() { systemCertPool = x509.SystemCertPool })
+	}
+
+	domain := srv.Listener.Addr().String()
+
+	t.Run("reads the certificate through the proxy", func(t *testing.T) {
+		withSystemPool(t, trusted)
+
+		expiry, err := certificateExpiry(proxied, domain)
+		assert.NoError(t, err)
+		assert.Equal(t, srv.Certificate().NotAfter, expiry)
+		assert.Equal(t, domain, <-tunneled)
+	})
+
+	t.Run("doesn't report the certificate of a proxy inspecting the traffic", func(t *testing.T) {
+		withSystemPool(t, x509.NewCertPool())
+
+		caFile := proxied.Clone()
+		caFile.TLSClientConfig = &tls.Config{RootCAs: trusted}
+
+		_, err := certificateExpiry(caFile, domain)
+		assert.Equal(t, errCertificateBehindProxy, err)
+		<-tunneled
+	})
+
+	t.Run("fails with an untrusted certificate", func(t *testing.T) {
+		withSystemPool(t, x509.NewCertPool())
+
+		_, err := certificateExpiry(proxied, domain)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "the certificate served isn't trusted")
+		<-tunneled
+	})
+
+	t.Run("fails with a certificate issued for another domain", func(t *testing.T) {
+		withSystemPool(t, trusted)
+		_, port, err := net.SplitHostPort(domain)
+		assert.NoError(t, err)
+
+		_, err = certificateExpiry(proxied, "localhost:"+port)
+		assert.Equal(t, errCertificateBehindProxy, err)
+		<-tunneled
+
+		_, err = certificateExpiry(&http.Transport{}, "localhost:"+port)
+		assert.EqualError(t, err, "the certificate served isn't issued for localhost")
+	})
+}
+
+func TestCheckCustomDomains(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	d := &tenantDoctorData{
+		domains: []*management.CustomDomain{
+			{Domain: auth0.String("expired.example.com"), Status: auth0.String("ready")},
+			{Domain: auth0.String("soon.example.com"), Status: auth0.String("ready")},
+			{Domain: auth0.String("fine.example.com"), Status: auth0.String("ready")},
+			{Domain: auth0.String("unreachable.example.com"), Status: auth0.String("ready")},
+			{Domain: auth0.String("pending.example.com"), Status: auth0.String("pending_verification")},
+		},
+		certificates: map[string]time.Time{
+			"expired.example.com": now.Add(-time.Hour),
+			"soon.example.com":    now.Add(7 * 24 * time.Hour),
+			"fine.example.com":    now.Add(60 * 24 * time.Hour),
+		},
+		certificatesErrs: map[string]error{
+			"unreachable.example.com": errors.New("i/o timeout"),
+		},
+	}
+
+	findings := checkCustomDomains(d, noLink, now)
+
+	assert.Len(t, findings, 4)
+	assert.Equal(t, display.DoctorPriorityHigh, findings[0].Priority)
+	assert.Equal(t, "The certificate of custom domain expired.example.com expired on 2021-05-31", findings[0].Problem)
+	assert.Equal(t, display.DoctorPriorityMedium, findings[1].Priority)
+	assert.Equal(t, "The certificate of custom domain soon.example.com expires on 2021-06-08", findings[1].Problem)
+	assert.Equal(t, display.DoctorPriorityMedium, findings[2].Priority)
+	assert.Contains(t, findings[2].Problem, "i/o timeout")
+	assert.Equal(t, display.DoctorPriorityLow, findings[3].Priority)
+	assert.Equal(t, "Custom domain pending.example.com isn't ready (pending_verification)", findings[3].Problem)
+}
+
+func TestCheckLogStreams(t *testing.T) {
+	d := &tenantDoctorData{
+		streams: []*management.LogStream{
+			{ID: auth0.String("lst_1"), Name: auth0.String("Datadog"), Status: auth0.String("active")},
+			{ID: auth0.String("lst_2"), Name: auth0.String("Splunk"), Status: auth0.String("suspended")},
+			{ID: auth0.String("lst_3"), Name: auth0.String("Webhook"), Status: auth0.String("paused")},
+		},
+	}
+
+	findings := checkLogStreams(d, noLink, time.Now())
+
+	assert.Len(t, findings, 2)
+	assert.Equal(t, display.DoctorPriorityHigh, findings[0].Priority)
+	assert.Equal(t, "Log stream Splunk is suspended", findings[0].Problem)
+	assert.Equal(t, display.DoctorPriorityLow, findings[1].Priority)
+}
+
+func TestRunTenantDoctorChecks(t *testing.T) {
+	d := &tenantDoctorData{
+		clientsErr: errors.New("insufficient scope"),
+		factors:    []*management.MultiFactor{{Name: auth0.String("otp"), Enabled: auth0.Bool(true)}},
+		streams:    []*management.LogStream{{ID: auth0.String("lst_1"), Name: auth0.String("Splunk"), Status: auth0.String("suspended")}},
+	}
+
+	checks := runTenantDoctorChecks(d, "https://manage.auth0.com/dashboard/us/travel0/", time.Now())
+
+	byName := make(map[string]display.DoctorCheck)
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+
+	assert.Len(t, checks, len(tenantDoctorChecks))
+	assert.Equal(t, "insufficient scope", byName["Application callbacks"].Error)
+	assert.Equal(t, "insufficient scope", byName["Unused rules"].Error)
+	assert.Empty(t, byName["Multi-factor authentication"].Findings)
+	assert.Equal(t, []display.DoctorFinding{{
+		Priority: display.DoctorPriorityHigh,
+		Check:    "Log streams",
+		Problem:  "Log stream Splunk is suspended",
+		URL:      "https://manage.auth0.com/dashboard/us/travel0/log-streams/lst_1/settings",
+	}}, byName["Log streams"].Findings)
+}
+
+func noLink(string) string {
+	return ""
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/auth0/auth0-cli/internal/auth"
+)
+
+// tenantSecret is a credential of a tenant which is kept in the secret store,
+// with only a reference to it in the config file.
+type tenantSecret struct {
+	name  string
+	value *string
+	ref   *string
+}
+
+func (t *tenant) secrets() []tenantSecret {
+	return []tenantSecret{
+		{name: "access_token", value: &t.AccessToken, ref: &t.AccessTokenRef},
+		{name: "client_secret", value: &t.ClientSecret, ref: &t.ClientSecretRef},
+	}
+}
+
+// secretKeys lists the keys of the secrets stored for the tenant: its refresh
+// token and the credentials referenced from the config file.
+func (t *tenant) secretKeys() []string {
+	keys := []string{t.Domain}
+	for _, s := range t.secrets() {
+		if *s.ref != "" {
+			keys = append(keys, *s.ref)
+		}
+	}
+	return keys
+}
+
+func tenantSecretKey(domain, name string) string {
+	return domain + ":" + name
+}
+
+// loadTenantSecrets reads the credentials of a tenant from the secret store.
+// A credential missing from the store is left empty, which leads to a new
+// login.
+func (c *cli) loadTenantSecrets(t *tenant) error {
+	for _, s := range t.secrets() {
+		if *s.value != "" || *s.ref == "" {
+			continue
+		}
+
+		v, err := c.secretStore().Get(auth.SecretsNamespace, *s.ref)
+		if errors.Is(err, auth.ErrSecretNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Unable to read the %s of %s: %w", strings.ReplaceAll(s.name, "_", " "), t.Domain, err)
+		}
+
+		*s.value = v
+		c.storedSecret(*s.ref, v)
+	}
+
+	return nil
+}
+
+// storeTenantSecrets moves the credentials of a tenant to the secret store,
+// replacing them with references. Credentials which are already stored aren't
+// written again.
+func (c *cli) storeTenantSecrets(t *tenant) error {
+	for _, s := range t.secrets() {
+		if *s.value == "" {
+			continue
+		}
+
+		key := tenantSecretKey(t.Domain, s.name)
+		if stored, ok := c.storedSecrets[key]; !ok || stored != *s.value {
+			if err := c.secretStore().Set(auth.SecretsNamespace, key, *s.value); err != nil {
+				return err
+			}
+			c.storedSecret(key, *s.value)
+		}
+
+		*s.ref = key
+		*s.value = ""
+	}
+
+	return nil
+}
+
+// deleteTenantSecrets removes the credentials of a tenant from the secret store.
+func (c *cli) deleteTenantSecrets(domain string) error {
+	for _, s := range (&tenant{}).secrets() {
+		key := tenantSecretKey(domain, s.name)
+
+		err := c.secretStore().Delete(auth.SecretsNamespace, key)
+		if err != nil && !errors.Is(err, auth.ErrSecretNotFound) {
+			return err
+		}
+		delete(c.storedSecrets, key)
+	}
+
+	return nil
+}
+
+// migrateTenantSecrets moves the credentials that earlier versions of the CLI
+// kept in plain text in the config file to the secret store.
+func (c *cli) migrateTenantSecrets() error {
+	for _, t := range c.config.Tenants {
+		if t.AccessToken != "" || t.ClientSecret != "" {
+			if err := c.persistConfig(); err != nil {
+				return fmt.Errorf("Unexpected error persisting config: %w", err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func (c *cli) storedSecret(key, value string) {
+	if c.storedSecrets == nil {
+		c.storedSecrets = map[string]string{}
+	}
+	c.storedSecrets[key] = value
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth"
+	"github.com/auth0/auth0-cli/internal/display"
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySecretStore struct {
+	secrets map[string]string
+	sets    int
+	err     error
+}
+
+func (m *memorySecretStore) Set(namespace, key, value string) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.secrets == nil {
+		m.secrets = map[string]string{}
+	}
+	m.secrets[namespace+"/"+key] = value
+	m.sets++
+	return nil
+}
+
+func (m *memorySecretStore) Get(namespace, key string) (string, error) {
+	v, ok := m.secrets[namespace+"/"+key]
+	if !ok {
+		return "", auth.ErrSecretNotFound
+	}
+	return v, nil
+}
+
+func (m *memorySecretStore) Delete(namespace, key string) error {
+	if _, ok := m.secrets[namespace+"/"+key]; !ok {
+		return auth.ErrSecretNotFound
+	}
+	delete(m.secrets, namespace+"/"+key)
+	return nil
+}
+
+func TestTenantSecrets(t *testing.T) {
+	newCLI := func(path string, store auth.SecretStore, messages *bytes.Buffer) *cli {
+		return &cli{
+			path:     path,
+			renderer: &display.Renderer{MessageWriter: messages},
+			secrets:  store,
+		}
+	}
+
+	ten := tenant{
+		Name:         "travel0",
+		Domain:       "travel0.auth0.com",
+		AccessToken:  "my-access-token",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		ClientID:     "my-client-id",
+		ClientSecret: "my-client-secret",
+	}
+
+	t.Run("credentials are only referenced from the config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		store := &memorySecretStore{}
+
+		c := newCLI(path, store, &bytes.Buffer{})
+		assert.NoError(t, c.addTenant(ten))
+
+		buf, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(buf), "my-access-token")
+		assert.NotContains(t, string(buf), "my-client-secret")
+		assert.Contains(t, string(buf), `"access_token_ref": "travel0.auth0.com:access_token"`)
+		assert.Contains(t, string(buf), `"client_secret_ref": "travel0.auth0.com:client_secret"`)
+
+		// Read them back, as another run of the cli would.
+		c = newCLI(path, store, &bytes.Buffer{})
+		got, err := c.getTenant()
+		assert.NoError(t, err)
+		assert.Equal(t, "my-access-token", got.AccessToken)
+		assert.Equal(t, "my-client-secret", got.ClientSecret)
+	})
+
+	t.Run("unchanged credentials aren't stored again", func(t *testing.T) {
+		store := &memorySecretStore{}
+
+		c := newCLI(filepath.Join(t.TempDir(), "config.json"), store, &bytes.Buffer{})
+		assert.NoError(t, c.addTenant(ten))
+		assert.NoError(t, c.persistConfig())
+		assert.Equal(t, 2, store.sets)
+
+		renewed := ten
+		renewed.AccessToken = "my-renewed-access-token"
+		assert.NoError(t, c.addTenant(renewed))
+		assert.Equal(t, 3, store.sets)
+	})
+
+	t.Run("plain text credentials are migrated", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		plain := `{"default_tenant": "travel0.auth0.com", "tenants": {"travel0.auth0.com": {"name": "travel0", "domain": "travel0.auth0.com", "access_token": "my-access-token", "client_id": "", "client_secret": ""}}}`
+		assert.NoError(t, ioutil.WriteFile(path, []byte(plain), 0600))
+
+		store := &memorySecretStore{}
+		c := newCLI(path, store, &bytes.Buffer{})
+		assert.NoError(t, c.init())
+		assert.NoError(t, c.migrateTenantSecrets())
+
+		buf, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(buf), "my-access-token")
+		assert.Equal(t, "my-access-token", store.secrets[auth.SecretsNamespace+"/travel0.auth0.com:access_token"])
+	})
+
+	t.Run("fails when credentials can't be stored", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		passphrase := errors.New("The OS keyring is unavailable and secrets are stored in an encrypted file: set AUTH0_SECRETS_PASSPHRASE to its passphrase")
+
+		c := newCLI(path, &memorySecretStore{err: passphrase}, &bytes.Buffer{})
+		err := c.addTenant(ten)
+		assert.True(t, errors.Is(err, passphrase))
+		assert.Contains(t, err.Error(), "set AUTH0_SECRETS_PASSPHRASE")
+
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err), "the credentials aren't written to the config file")
+	})
+
+	t.Run("plain text credentials aren't rewritten when they can't be stored", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		plain := `{"default_tenant": "travel0.auth0.com", "tenants": {"travel0.auth0.com": {"name": "travel0", "domain": "travel0.auth0.com", "access_token": "my-access-token", "client_id": "", "client_secret": ""}}}`
+		assert.NoError(t, ioutil.WriteFile(path, []byte(plain), 0600))
+
+		c := newCLI(path, &memorySecretStore{err: errors.New("no passphrase")}, &bytes.Buffer{})
+		assert.NoError(t, c.init())
+		assert.EqualError(t, c.migrateTenantSecrets(), "Unexpected error persisting config: Unable to store the credentials of travel0.auth0.com securely: no passphrase")
+
+		buf, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, plain, string(buf))
+	})
+
+	t.Run("credentials are deleted with the tenant", func(t *testing.T) {
+		store := &memorySecretStore{}
+
+		c := newCLI(filepath.Join(t.TempDir(), "config.json"), store, &bytes.Buffer{})
+		assert.NoError(t, c.addTenant(ten))
+		assert.NoError(t, store.Set(auth.SecretsNamespace, ten.Domain, "my-refresh-token"))
+
+		assert.NoError(t, c.removeTenant(ten.Domain))
+		assert.Empty(t, store.secrets)
+	})
+}
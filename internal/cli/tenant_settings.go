@@ -0,0 +1,437 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/prompt"
+	"github.com/auth0/go-auth0/management"
+	"github.com/spf13/cobra"
+)
+
+// tenantSettingsReadOnly are the settings the management API returns but
+// doesn't allow to update.
+var tenantSettingsReadOnly = []string{"sandbox_versions_available"}
+
+var (
+	tenantFriendlyName = Flag{
+		Name:         "Friendly Name",
+		LongForm:     "friendly-name",
+		ShortForm:    "n",
+		Help:         "Name of the tenant shown to users, e.g. on the login page.",
+		AlwaysPrompt: true,
+	}
+
+	tenantSupportEmail = Flag{
+		Name:         "Support Email",
+		LongForm:     "support-email",
+		Help:         "Email address users can contact for support.",
+		AlwaysPrompt: true,
+	}
+
+	tenantSupportURL = Flag{
+		Name:         "Support URL",
+		LongForm:     "support-url",
+		Help:         "URL of the support page for users.",
+		AlwaysPrompt: true,
+	}
+
+	tenantSessionLifetime = Flag{
+		Name:     "Session Lifetime",
+		LongForm: "session-lifetime",
+		Help:     "How long a session lasts before the user has to log in again, in whole hours, or minutes under an hour, e.g. 168h.",
+	}
+
+	tenantIdleSessionLifetime = Flag{
+		Name:     "Idle Session Lifetime",
+		LongForm: "idle-session-lifetime",
+		Help:     "How long a session lasts without activity before the user has to log in again, in whole hours, or minutes under an hour, e.g. 72h.",
+	}
+
+	tenantDefaultAudience = Flag{
+		Name:     "Default Audience",
+		LongForm: "default-audience",
+		Help:     "API identifier used as the audience of access tokens when none is requested.",
+	}
+
+	tenantDefaultDirectory = Flag{
+		Name:     "Default Directory",
+		LongForm: "default-directory",
+		Help:     "Name of the connection used for the password grant when none is given.",
+	}
+
+	tenantEnabledLocales = Flag{
+		Name:     "Enabled Locales",
+		LongForm: "enabled-locales",
+		Help:     "Comma-separated list of the locales supported by the tenant, the first one being the default, e.g. en,es,fr.",
+	}
+
+	tenantAllowedLogoutURLs = Flag{
+		Name:     "Allowed Logout URLs",
+		LongForm: "allowed-logout-urls",
+		Help:     "Comma-separated list of URLs users can be redirected to after logging out, for logouts without a client.",
+	}
+
+	tenantFlags = Flag{
+		Name:     "Flags",
+		LongForm: "flags",
+		Help:     "Flags of the tenant to turn on or off, e.g. enable_sso=true,disable_impersonation=false.",
+	}
+
+	tenantSettingsJSON = Flag{
+		Name:     "JSON",
+		LongForm: "json",
+		Help:     "Edit the settings as JSON in your editor.",
+	}
+
+	tenantSettingsFile = Flag{
+		Name:     "File",
+		LongForm: "file",
+		Help:     "Path to a JSON file of settings to apply, e.g. saved with 'auth0 tenants settings show --format json'.",
+	}
+)
+
+type tenantSettingsInputs struct {
+	FriendlyName        string
+	SupportEmail        string
+	SupportURL          string
+	SessionLifetime     time.Duration
+	IdleSessionLifetime time.Duration
+	DefaultAudience     string
+	DefaultDirectory    string
+	EnabledLocales      []string
+	AllowedLogoutURLs   []string
+	Flags               map[string]string
+	JSON                bool
+	File                string
+}
+
+func tenantSettingsCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Manage the settings of the tenant",
+		Long:  "Manage the settings of the active tenant.",
+	}
+
+	cmd.SetUsageTemplate(resourceUsageTemplate())
+	cmd.AddCommand(showTenantSettingsCmd(cli))
+	cmd.AddCommand(updateTenantSettingsCmd(cli))
+
+	return cmd
+}
+
+func showTenantSettingsCmd(cli *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Args:  cobra.NoArgs,
+		Short: "Show the settings of the tenant",
+		Long:  "Show the settings of the active tenant.",
+		Example: `auth0 tenants settings show
+auth0 tenants settings show --format json > settings.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var t *management.Tenant
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				t, err = cli.api.Tenant.Read()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the tenant settings: %w", err)
+			}
+
+			cli.renderer.TenantSettingsShow(t)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func updateTenantSettingsCmd(cli *cli) *cobra.Command {
+	var inputs tenantSettingsInputs
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Args:  cobra.NoArgs,
+		Short: "Update the settings of the tenant",
+		Long: `Update the settings of the active tenant. Settings that aren't given are left
+unchanged.
+
+Use --json to edit all the settings as JSON in your editor, or --file to apply
+settings saved from another tenant with 'auth0 tenants settings show --format json'.
+Only the settings that differ from the current ones are updated.`,
+		Example: `auth0 tenants settings update
+auth0 tenants settings update --friendly-name "Travel0" --support-email support@travel0.com
+auth0 tenants settings update --session-lifetime 168h --idle-session-lifetime 72h
+auth0 tenants settings update --enabled-locales en,es --flags enable_sso=true
+auth0 tenants settings update --json
+auth0 tenants settings update --file settings.json --tenant travel0-staging.us.auth0.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var current *management.Tenant
+
+			if err := ansi.Waiting(func() error {
+				var err error
+				current, err = cli.api.Tenant.Read()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the tenant settings: %w", err)
+			}
+
+			var t *management.Tenant
+			var err error
+
+			switch {
+			case inputs.File != "":
+				var buf []byte
+				if buf, err = ioutil.ReadFile(inputs.File); err != nil {
+					return fmt.Errorf("Unable to read the settings file: %w", err)
+				}
+				t, err = tenantSettingsPatch(current, buf)
+			case inputs.JSON:
+				t, err = editTenantSettings(current)
+			default:
+				t, err = askTenantSettings(cmd, current, &inputs)
+			}
+			if err != nil {
+				return err
+			}
+
+			if reflect.DeepEqual(t, &management.Tenant{}) {
+				cli.renderer.Infof("The tenant settings are unchanged.")
+				return nil
+			}
+
+			if err := ansi.Waiting(func() error {
+				return cli.api.Tenant.Update(t)
+			}); err != nil {
+				return fmt.Errorf("Unable to update the tenant settings: %w", err)
+			}
+
+			if err := ansi.Waiting(func() error {
+				current, err = cli.api.Tenant.Read()
+				return err
+			}); err != nil {
+				return fmt.Errorf("Unable to get the tenant settings: %w", err)
+			}
+
+			cli.renderer.TenantSettingsUpdate(current)
+			return nil
+		},
+	}
+
+	tenantFriendlyName.RegisterStringU(cmd, &inputs.FriendlyName, "")
+	tenantSupportEmail.RegisterStringU(cmd, &inputs.SupportEmail, "")
+	tenantSupportURL.RegisterStringU(cmd, &inputs.SupportURL, "")
+	tenantSessionLifetime.RegisterDurationU(cmd, &inputs.SessionLifetime, 0)
+	tenantIdleSessionLifetime.RegisterDurationU(cmd, &inputs.IdleSessionLifetime, 0)
+	tenantDefaultAudience.RegisterStringU(cmd, &inputs.DefaultAudience, "")
+	tenantDefaultDirectory.RegisterStringU(cmd, &inputs.DefaultDirectory, "")
+	tenantEnabledLocales.RegisterStringSliceU(cmd, &inputs.EnabledLocales, nil)
+	tenantAllowedLogoutURLs.RegisterStringSliceU(cmd, &inputs.AllowedLogoutURLs, nil)
+	tenantFlags.RegisterStringMapU(cmd, &inputs.Flags, nil)
+	tenantSettingsJSON.RegisterBool(cmd, &inputs.JSON, false)
+	tenantSettingsFile.RegisterString(cmd, &inputs.File, "")
+
+	return cmd
+}
+
+// askTenantSettings builds the settings to update from the flags, prompting
+// for the main ones when no flag is given.
+func askTenantSettings(cmd *cobra.Command, current *management.Tenant, inputs *tenantSettingsInputs) (*management.Tenant, error) {
+	if err := tenantFriendlyName.AskU(cmd, &inputs.FriendlyName, current.FriendlyName); err != nil {
+		return nil, err
+	}
+	if err := tenantSupportEmail.AskU(cmd, &inputs.SupportEmail, current.SupportEmail); err != nil {
+		return nil, err
+	}
+	if err := tenantSupportURL.AskU(cmd, &inputs.SupportURL, current.SupportURL); err != nil {
+		return nil, err
+	}
+
+	t := &management.Tenant{}
+
+	if inputs.FriendlyName != "" && inputs.FriendlyName != current.GetFriendlyName() {
+		t.FriendlyName = &inputs.FriendlyName
+	}
+	if inputs.SupportEmail != "" && inputs.SupportEmail != current.GetSupportEmail() {
+		t.SupportEmail = &inputs.SupportEmail
+	}
+	if inputs.SupportURL != "" && inputs.SupportURL != current.GetSupportURL() {
+		t.SupportURL = &inputs.SupportURL
+	}
+
+	var err error
+	if tenantSessionLifetime.IsSet(cmd) {
+		if t.SessionLifetime, err = tenantLifetimeFor(tenantSessionLifetime, inputs.SessionLifetime); err != nil {
+			return nil, err
+		}
+	}
+	if tenantIdleSessionLifetime.IsSet(cmd) {
+		if t.IdleSessionLifetime, err = tenantLifetimeFor(tenantIdleSessionLifetime, inputs.IdleSessionLifetime); err != nil {
+			return nil, err
+		}
+	}
+
+	if tenantDefaultAudience.IsSet(cmd) {
+		t.DefaultAudience = &inputs.DefaultAudience
+	}
+	if tenantDefaultDirectory.IsSet(cmd) {
+		t.DefaultDirectory = &inputs.DefaultDirectory
+	}
+
+	if tenantEnabledLocales.IsSet(cmd) {
+		t.EnabledLocales = stringToInterfaceSlice(inputs.EnabledLocales)
+	}
+	if tenantAllowedLogoutURLs.IsSet(cmd) {
+		t.AllowedLogoutURLs = stringToInterfaceSlice(inputs.AllowedLogoutURLs)
+	}
+
+	if tenantFlags.IsSet(cmd) {
+		flags, err := tenantFlagsFor(inputs.Flags)
+		if err != nil {
+			return nil, err
+		}
+		t.Flags = flags
+	}
+
+	return t, nil
+}
+
+// editTenantSettings opens the current settings as JSON in an editor and
+// returns the ones that were changed.
+func editTenantSettings(current *management.Tenant) (*management.Tenant, error) {
+	editable := *current
+	editable.SandboxVersionAvailable = nil
+
+	buf, err := json.MarshalIndent(editable, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	edited, err := prompt.CaptureInputViaEditor(string(buf), "tenant-settings.*.json", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to capture input from the editor: %w", err)
+	}
+
+	return tenantSettingsPatch(current, []byte(edited))
+}
+
+// tenantSettingsPatch returns the settings of buf that differ from the
+// current ones. Flags are compared one by one, since they're updated as such.
+func tenantSettingsPatch(current *management.Tenant, buf []byte) (*management.Tenant, error) {
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+
+	var wanted management.Tenant
+	if err := dec.Decode(&wanted); err != nil {
+		return nil, fmt.Errorf("Invalid tenant settings: %w", err)
+	}
+
+	currentValues, err := jsonValues(current)
+	if err != nil {
+		return nil, err
+	}
+	wantedValues, err := jsonValues(&wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range tenantSettingsReadOnly {
+		delete(wantedValues, key)
+	}
+
+	patch := map[string]interface{}{}
+	for key, value := range wantedValues {
+		if key == "flags" {
+			currentFlags, _ := currentValues[key].(map[string]interface{})
+			wantedFlags, _ := value.(map[string]interface{})
+
+			changed := map[string]interface{}{}
+			for name, v := range wantedFlags {
+				if !reflect.DeepEqual(currentFlags[name], v) {
+					changed[name] = v
+				}
+			}
+			if len(changed) > 0 {
+				patch[key] = changed
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(currentValues[key], value) {
+			patch[key] = value
+		}
+	}
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &management.Tenant{}
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func jsonValues(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	err = json.Unmarshal(b, &values)
+	return values, err
+}
+
+// tenantFlagsFor turns the flags given as name=true|false into tenant flags.
+func tenantFlagsFor(flags map[string]string) (*management.TenantFlags, error) {
+	values := map[string]bool{}
+	for name, v := range flags {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value '%s' for flag %s, expected true or false", v, name)
+		}
+		values[name] = b
+	}
+
+	buf, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+
+	t := &management.TenantFlags{}
+	if err := dec.Decode(t); err != nil {
+		return nil, fmt.Errorf("Invalid tenant flags: %w", err)
+	}
+	return t, nil
+}
+
+// tenantLifetimeFor turns a session lifetime into hours, as the API sets it.
+// Lifetimes under an hour are sent in minutes by management.Tenant, while
+// longer ones are rounded to hours, so they must be whole hours.
+func tenantLifetimeFor(f Flag, d time.Duration) (*float64, error) {
+	switch {
+	case d <= 0:
+		return nil, fmt.Errorf("Invalid %s %s, expected a positive duration", strings.ToLower(f.Name), d)
+	case d < time.Hour && d%time.Minute != 0:
+		return nil, fmt.Errorf("Invalid %s %s, expected whole minutes under an hour, e.g. 30m", strings.ToLower(f.Name), d)
+	case d >= time.Hour && d%time.Hour != 0:
+		return nil, fmt.Errorf("Invalid %s %s, expected whole hours, e.g. 2h", strings.ToLower(f.Name), d)
+	}
+
+	hours := d.Hours()
+	return &hours, nil
+}
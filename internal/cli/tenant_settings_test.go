@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantSettingsPatch(t *testing.T) {
+	current := &management.Tenant{
+		FriendlyName:            auth0.String("Travel0"),
+		SupportEmail:            auth0.String("support@travel0.com"),
+		SessionLifetime:         auth0.Float64(168),
+		EnabledLocales:          []interface{}{"en"},
+		SandboxVersionAvailable: []interface{}{"12", "16"},
+		Flags: &management.TenantFlags{
+			EnableSSO:            auth0.Bool(true),
+			DisableImpersonation: auth0.Bool(false),
+		},
+	}
+
+	t.Run("only what changed", func(t *testing.T) {
+		patch, err := tenantSettingsPatch(current, []byte(`{
+			"friendly_name": "Travel0",
+			"support_email": "help@travel0.com",
+			"session_lifetime": 72,
+			"enabled_locales": ["en"],
+			"sandbox_versions_available": ["16"],
+			"flags": {"enable_sso": true, "disable_impersonation": true}
+		}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &management.Tenant{
+			SupportEmail:    auth0.String("help@travel0.com"),
+			SessionLifetime: auth0.Float64(72),
+			Flags:           &management.TenantFlags{DisableImpersonation: auth0.Bool(true)},
+		}, patch)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		patch, err := tenantSettingsPatch(current, []byte(`{"friendly_name": "Travel0", "flags": {"enable_sso": true}}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &management.Tenant{}, patch)
+	})
+
+	t.Run("unknown setting", func(t *testing.T) {
+		_, err := tenantSettingsPatch(current, []byte(`{"friendly_nam": "Travel0"}`))
+		assert.EqualError(t, err, `Invalid tenant settings: json: unknown field "friendly_nam"`)
+	})
+}
+
+func TestTenantFlagsFor(t *testing.T) {
+	flags, err := tenantFlagsFor(map[string]string{"enable_sso": "true", "disable_impersonation": "false"})
+	assert.NoError(t, err)
+	assert.Equal(t, &management.TenantFlags{
+		EnableSSO:            auth0.Bool(true),
+		DisableImpersonation: auth0.Bool(false),
+	}, flags)
+
+	_, err = tenantFlagsFor(map[string]string{"enable_sso": "yes please"})
+	assert.EqualError(t, err, "Invalid value 'yes please' for flag enable_sso, expected true or false")
+
+	_, err = tenantFlagsFor(map[string]string{"enable_everything": "true"})
+	assert.EqualError(t, err, `Invalid tenant flags: json: unknown field "enable_everything"`)
+}
+
+func TestTenantLifetimeFor(t *testing.T) {
+	tests := []struct {
+		lifetime time.Duration
+		json     string
+	}{
+		{72 * time.Hour, `{"session_lifetime":72}`},
+		{30 * time.Minute, `{"session_lifetime_in_minutes":30}`},
+	}
+
+	for _, test := range tests {
+		lifetime, err := tenantLifetimeFor(tenantSessionLifetime, test.lifetime)
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(&management.Tenant{SessionLifetime: lifetime})
+		assert.NoError(t, err)
+		assert.JSONEq(t, test.json, string(b))
+	}
+
+	_, err := tenantLifetimeFor(tenantSessionLifetime, 90*time.Minute)
+	assert.EqualError(t, err, "Invalid session lifetime 1h30m0s, expected whole hours, e.g. 2h")
+
+	_, err = tenantLifetimeFor(tenantSessionLifetime, 90*time.Second)
+	assert.EqualError(t, err, "Invalid session lifetime 1m30s, expected whole minutes under an hour, e.g. 30m")
+
+	_, err = tenantLifetimeFor(tenantIdleSessionLifetime, 0)
+	assert.EqualError(t, err, "Invalid idle session lifetime 0s, expected a positive duration")
+}
@@ -43,6 +43,8 @@ func tenantsCmd(cli *cli) *cobra.Command {
 	cmd.AddCommand(listTenantCmd(cli))
 	cmd.AddCommand(openTenantCmd(cli))
 	cmd.AddCommand(addTenantCmd(cli))
+	cmd.AddCommand(tenantSettingsCmd(cli))
+	cmd.AddCommand(tenantDoctorCmd(cli))
 	return cmd
 }
 
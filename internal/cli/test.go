@@ -167,7 +167,7 @@ auth0 test login <client-id> --connection <connection>`,
 			if err := ansi.Spinner("Fetching user metadata", func() error {
 				// Use the access token to fetch user information from the /userinfo
 				// endpoint.
-				userInfo, err = authutil.FetchUserInfo(tenant.Domain, tokenResponse.AccessToken)
+				userInfo, err = authutil.FetchUserInfo(cli.defaultClient(), tenant.Domain, tokenResponse.AccessToken)
 				return err
 			}); err != nil {
 				return fmt.Errorf("An unexpected error occurred: %w", err)
@@ -223,10 +223,13 @@ auth0 test token --client-id <id> --audience <audience> --scopes <scope1,scope2>
 				return err
 			}
 
-			// use the client ID as passed in by the user, or default to the
-			// "CLI Login Testing" client if none passed. This client is only
-			// used for testing login from the CLI and will be created if it
-			// does not exist.
+			// use the client ID as passed in by the user, or the app of the
+			// active profile, or default to the "CLI Login Testing" client if
+			// none passed. This client is only used for testing login from
+			// the CLI and will be created if it does not exist.
+			if inputs.ClientID == "" {
+				inputs.ClientID = cli.profileAppID(tenant)
+			}
 			if inputs.ClientID == "" {
 				client, err := getOrCreateCLITesterClient(cli.api.Client)
 				if err != nil {
@@ -7,7 +7,6 @@ import (
 
 	"encoding/base64"
 	"encoding/json"
-	"net/http"
 	"net/url"
 
 	"github.com/auth0/auth0-cli/internal/ansi"
@@ -65,7 +64,7 @@ func runClientCredentialsFlow(cli *cli, c *management.Client, clientID string, a
 	// TODO: Check if the audience is valid, and suggest a different client if it is wrong.
 
 	err := ansi.Spinner("Waiting for token", func() error {
-		res, err := http.PostForm(tokenURL, payload)
+		res, err := cli.defaultClient().PostForm(tokenURL, payload)
 		if err != nil {
 			return err
 		}
@@ -154,6 +153,7 @@ func runLoginFlow(cli *cli, t tenant, c *management.Client, connName, audience,
 		// once the callback is received, exchange the code for an access
 		// token.
 		tokenResponse, err = authutil.ExchangeCodeForToken(
+			cli.defaultClient(),
 			t.Domain,
 			c.GetClientID(),
 			c.GetClientSecret(),
@@ -274,7 +274,18 @@ func generateState(size int) (string, error) {
 }
 
 // check if slice contains a string
-func containsStr(s []interface{}, u string) bool {
+func containsStr(s []string, u string) bool {
+	for _, a := range s {
+		if a == u {
+			return true
+		}
+	}
+	return false
+}
+
+// check if slice of values, e.g. the URLs of a management.Client, contains
+// a string
+func containsValue(s []interface{}, u string) bool {
 	for _, a := range s {
 		if a == u {
 			return true
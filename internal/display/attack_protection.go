@@ -0,0 +1,209 @@
+package display
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/go-auth0/management"
+)
+
+// BlockedIP is an IP address blocked by suspicious IP throttling.
+type BlockedIP struct {
+	IP          string    `json:"ip"`
+	LastBlocked time.Time `json:"last_blocked"`
+	Blocks      int       `json:"blocks"`
+	Location    string    `json:"location,omitempty"`
+}
+
+type bruteForceProtectionView struct {
+	*management.BruteForceProtection
+}
+
+func (v *bruteForceProtectionView) AsTableHeader() []string {
+	return []string{}
+}
+
+func (v *bruteForceProtectionView) AsTableRow() []string {
+	return []string{}
+}
+
+func (v *bruteForceProtectionView) KeyValues() [][]string {
+	return [][]string{
+		{"ENABLED", boolean(v.GetEnabled())},
+		{"SHIELDS", protectionList(v.Shields)},
+		{"ALLOWLIST", protectionList(v.AllowList)},
+		{"MODE", v.GetMode()},
+		{"MAX ATTEMPTS", strconv.Itoa(v.GetMaxAttempts())},
+	}
+}
+
+func (v *bruteForceProtectionView) Object() interface{} {
+	return v.BruteForceProtection
+}
+
+type suspiciousIPThrottlingView struct {
+	*management.SuspiciousIPThrottling
+}
+
+func (v *suspiciousIPThrottlingView) AsTableHeader() []string {
+	return []string{}
+}
+
+func (v *suspiciousIPThrottlingView) AsTableRow() []string {
+	return []string{}
+}
+
+func (v *suspiciousIPThrottlingView) KeyValues() [][]string {
+	var login management.PreLogin
+	var signup management.PreUserRegistration
+	if stage := v.GetStage(); stage != nil {
+		if stage.PreLogin != nil {
+			login = *stage.PreLogin
+		}
+		if stage.PreUserRegistration != nil {
+			signup = *stage.PreUserRegistration
+		}
+	}
+
+	return [][]string{
+		{"ENABLED", boolean(v.GetEnabled())},
+		{"SHIELDS", protectionList(v.Shields)},
+		{"ALLOWLIST", protectionList(v.AllowList)},
+		{"LOGIN MAX ATTEMPTS", strconv.Itoa(login.GetMaxAttempts())},
+		{"LOGIN RATE", protectionRate(login.GetRate())},
+		{"SIGNUP MAX ATTEMPTS", strconv.Itoa(signup.GetMaxAttempts())},
+		{"SIGNUP RATE", protectionRate(signup.GetRate())},
+	}
+}
+
+func (v *suspiciousIPThrottlingView) Object() interface{} {
+	return v.SuspiciousIPThrottling
+}
+
+type breachedPasswordDetectionView struct {
+	*management.BreachedPasswordDetection
+}
+
+func (v *breachedPasswordDetectionView) AsTableHeader() []string {
+	return []string{}
+}
+
+func (v *breachedPasswordDetectionView) AsTableRow() []string {
+	return []string{}
+}
+
+func (v *breachedPasswordDetectionView) KeyValues() [][]string {
+	return [][]string{
+		{"ENABLED", boolean(v.GetEnabled())},
+		{"SHIELDS", protectionList(v.Shields)},
+		{"ADMIN NOTIFICATION FREQUENCY", protectionList(v.AdminNotificationFrequency)},
+		{"METHOD", v.GetMethod()},
+	}
+}
+
+func (v *breachedPasswordDetectionView) Object() interface{} {
+	return v.BreachedPasswordDetection
+}
+
+type blockedIPView struct {
+	BlockedIP
+}
+
+func (v *blockedIPView) AsTableHeader() []string {
+	return []string{"IP", "Last Blocked", "Blocks", "Location"}
+}
+
+func (v *blockedIPView) AsTableRow() []string {
+	return []string{
+		v.IP,
+		ansi.Faint(timeAgo(v.LastBlocked)),
+		strconv.Itoa(v.Blocks),
+		v.Location,
+	}
+}
+
+func (v *blockedIPView) Object() interface{} {
+	return v.BlockedIP
+}
+
+func (r *Renderer) AttackProtectionShow(bfp *management.BruteForceProtection, sit *management.SuspiciousIPThrottling, bpd *management.BreachedPasswordDetection) {
+	if r.Format == OutputFormatJSON {
+		r.Heading("attack protection")
+		r.JSONResult(struct {
+			BruteForceProtection      *management.BruteForceProtection      `json:"brute_force_protection"`
+			SuspiciousIPThrottling    *management.SuspiciousIPThrottling    `json:"suspicious_ip_throttling"`
+			BreachedPasswordDetection *management.BreachedPasswordDetection `json:"breached_password_detection"`
+		}{bfp, sit, bpd})
+		return
+	}
+
+	r.BruteForceProtectionShow(bfp)
+	r.SuspiciousIPThrottlingShow(sit)
+	r.BreachedPasswordDetectionShow(bpd)
+}
+
+func (r *Renderer) BruteForceProtectionShow(bfp *management.BruteForceProtection) {
+	r.Heading("brute force protection")
+	r.Result(&bruteForceProtectionView{bfp})
+}
+
+func (r *Renderer) BruteForceProtectionUpdate(bfp *management.BruteForceProtection) {
+	r.Heading("brute force protection updated")
+	r.Result(&bruteForceProtectionView{bfp})
+}
+
+func (r *Renderer) SuspiciousIPThrottlingShow(sit *management.SuspiciousIPThrottling) {
+	r.Heading("suspicious IP throttling")
+	r.Result(&suspiciousIPThrottlingView{sit})
+}
+
+func (r *Renderer) SuspiciousIPThrottlingUpdate(sit *management.SuspiciousIPThrottling) {
+	r.Heading("suspicious IP throttling updated")
+	r.Result(&suspiciousIPThrottlingView{sit})
+}
+
+func (r *Renderer) BreachedPasswordDetectionShow(bpd *management.BreachedPasswordDetection) {
+	r.Heading("breached password detection")
+	r.Result(&breachedPasswordDetectionView{bpd})
+}
+
+func (r *Renderer) BreachedPasswordDetectionUpdate(bpd *management.BreachedPasswordDetection) {
+	r.Heading("breached password detection updated")
+	r.Result(&breachedPasswordDetectionView{bpd})
+}
+
+func (r *Renderer) BlockedIPList(ips []BlockedIP) {
+	resource := "blocked IPs"
+
+	r.Heading(resource)
+
+	if len(ips) == 0 {
+		r.EmptyState(resource)
+		return
+	}
+
+	var res []View
+	for _, ip := range ips {
+		res = append(res, &blockedIPView{ip})
+	}
+
+	r.Results(res)
+}
+
+func protectionList(v *[]string) string {
+	if v == nil || len(*v) == 0 {
+		return ansi.Faint("none")
+	}
+	return strings.Join(*v, ", ")
+}
+
+// protectionRate shows the interval at which suspicious IP throttling grants
+// a new attempt, which the API sets in milliseconds.
+func protectionRate(ms int) string {
+	if ms == 0 {
+		return ""
+	}
+	return "1 attempt every " + (time.Duration(ms) * time.Millisecond).String()
+}
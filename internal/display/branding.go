@@ -1,6 +1,10 @@
 package display
 
 import (
+	"strconv"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/branding"
 	"github.com/auth0/go-auth0/management"
 )
 
@@ -55,3 +59,51 @@ func makeBrandingView(data *management.Branding) *brandingView {
 		raw:             data,
 	}
 }
+
+type templateLintView struct {
+	Severity string
+	Line     string
+	Message  string
+	raw      interface{}
+}
+
+func (v *templateLintView) AsTableHeader() []string {
+	return []string{"Severity", "Line", "Message"}
+}
+
+func (v *templateLintView) AsTableRow() []string {
+	severity := ansi.Yellow(v.Severity)
+	if v.Severity == branding.LintError {
+		severity = ansi.Red(v.Severity)
+	}
+	return []string{severity, ansi.Faint(v.Line), v.Message}
+}
+
+func (v *templateLintView) Object() interface{} {
+	return v.raw
+}
+
+func (r *Renderer) TemplateLint(issues []branding.LintIssue) {
+	r.Heading("template lint")
+
+	if len(issues) == 0 {
+		r.Infof("No issues found.")
+		return
+	}
+
+	var res []View
+	for _, issue := range issues {
+		line := ""
+		if issue.Line > 0 {
+			line = strconv.Itoa(issue.Line)
+		}
+		res = append(res, &templateLintView{
+			Severity: issue.Severity,
+			Line:     line,
+			Message:  issue.Message,
+			raw:      issue,
+		})
+	}
+
+	r.Results(res)
+}
@@ -1,6 +1,11 @@
 package display
 
-import "github.com/auth0/auth0-cli/internal/ansi"
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+)
 
 func (r *Renderer) BrandingTextShow(b string) {
 	r.Heading("custom texts")
@@ -11,3 +16,68 @@ func (r *Renderer) BrandingTextUpdate(b string) {
 	r.Heading("custom texts updated")
 	r.Output(ansi.ColorizeJSON(b, false))
 }
+
+// BrandingTextsCompleteness reports the custom text keys of a language
+// compared to the ones of the default language.
+type BrandingTextsCompleteness struct {
+	Language string   `json:"language"`
+	Keys     int      `json:"keys"`
+	Total    int      `json:"total"`
+	Missing  []string `json:"missing"`
+}
+
+type brandingTextsCompletenessView struct {
+	Language string
+	Keys     string
+	Missing  string
+	Complete string
+	raw      interface{}
+}
+
+func (v *brandingTextsCompletenessView) AsTableHeader() []string {
+	return []string{"Language", "Keys", "Missing", "Complete"}
+}
+
+func (v *brandingTextsCompletenessView) AsTableRow() []string {
+	return []string{v.Language, v.Keys, v.Missing, v.Complete}
+}
+
+func (v *brandingTextsCompletenessView) Object() interface{} {
+	return v.raw
+}
+
+func (r *Renderer) BrandingTextsCompleteness(report []BrandingTextsCompleteness) {
+	r.Heading("custom texts completeness")
+
+	var res []View
+	for _, item := range report {
+		complete := 100
+		if item.Total > 0 {
+			complete = (item.Total - len(item.Missing)) * 100 / item.Total
+		}
+
+		missing := strconv.Itoa(len(item.Missing))
+		if len(item.Missing) > 0 {
+			missing = ansi.Yellow(missing)
+		}
+
+		res = append(res, &brandingTextsCompletenessView{
+			Language: item.Language,
+			Keys:     strconv.Itoa(item.Keys),
+			Missing:  missing,
+			Complete: fmt.Sprintf("%d%%", complete),
+			raw:      item,
+		})
+	}
+
+	r.Results(res)
+
+	if r.Format != OutputFormatJSON {
+		for _, item := range report {
+			if len(item.Missing) > 0 {
+				r.Infof("%s Use '--format json' to list the missing keys of each language.", ansi.Faint("Hint:"))
+				break
+			}
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package display
 
 import (
+	"strings"
+
 	"github.com/auth0/auth0-cli/internal/ansi"
 	"github.com/auth0/go-auth0/management"
 )
@@ -106,3 +108,88 @@ func customDomainStatusColor(v string) string {
 		return v
 	}
 }
+
+// CustomDomainRecordCheck is the outcome of looking up one of the DNS records
+// a custom domain needs, on one resolver.
+type CustomDomainRecordCheck struct {
+	Resolver string   `json:"resolver"`
+	Type     string   `json:"type"`
+	Name     string   `json:"name"`
+	Expected string   `json:"expected"`
+	Found    []string `json:"found"`
+	OK       bool     `json:"ok"`
+	Problem  string   `json:"problem,omitempty"`
+}
+
+type customDomainRecordCheckView struct {
+	Resolver string
+	Type     string
+	Name     string
+	Found    string
+	Status   string
+	raw      interface{}
+}
+
+func (v *customDomainRecordCheckView) AsTableHeader() []string {
+	return []string{"Resolver", "Type", "Name", "Found", "Status"}
+}
+
+func (v *customDomainRecordCheckView) AsTableRow() []string {
+	return []string{v.Resolver, v.Type, v.Name, v.Found, v.Status}
+}
+
+func (v *customDomainRecordCheckView) KeyValues() [][]string {
+	return [][]string{
+		{"RESOLVER", v.Resolver},
+		{"TYPE", v.Type},
+		{"NAME", v.Name},
+		{"FOUND", v.Found},
+		{"STATUS", v.Status},
+	}
+}
+
+func (v *customDomainRecordCheckView) Object() interface{} {
+	return v.raw
+}
+
+func (r *Renderer) CustomDomainCheck(customDomain *management.CustomDomain, checks []CustomDomainRecordCheck) {
+	r.Heading("custom domain check", customDomain.GetDomain(), customDomainStatusColor(customDomain.GetStatus()))
+
+	var res []View
+	for _, check := range checks {
+		status := ansi.Green("ok")
+		if !check.OK {
+			status = ansi.Red("failed")
+		}
+
+		found := strings.Join(check.Found, ", ")
+		if found == "" {
+			found = ansi.Faint("none")
+		}
+
+		res = append(res, &customDomainRecordCheckView{
+			Resolver: check.Resolver,
+			Type:     check.Type,
+			Name:     check.Name,
+			Found:    found,
+			Status:   status,
+			raw:      check,
+		})
+	}
+	r.Results(res)
+
+	// The same problem is usually reported by every resolver, so only
+	// explain it once.
+	seen := map[string]bool{}
+	for _, check := range checks {
+		if check.OK || seen[check.Problem] {
+			continue
+		}
+		seen[check.Problem] = true
+		r.Warnf("%s", check.Problem)
+	}
+
+	if len(seen) == 0 {
+		r.Infof("All DNS records are in place.")
+	}
+}
@@ -0,0 +1,114 @@
+package display
+
+import (
+	"strconv"
+
+	"github.com/auth0/go-auth0/management"
+)
+
+type emailProviderView struct {
+	Provider    string
+	Enabled     string
+	DefaultFrom string
+	Settings    [][]string
+	raw         interface{}
+}
+
+func (v *emailProviderView) AsTableHeader() []string {
+	return []string{}
+}
+
+func (v *emailProviderView) AsTableRow() []string {
+	return []string{}
+}
+
+func (v *emailProviderView) KeyValues() [][]string {
+	kvs := [][]string{
+		{"PROVIDER", v.Provider},
+		{"ENABLED", v.Enabled},
+		{"DEFAULT FROM", v.DefaultFrom},
+	}
+	return append(kvs, v.Settings...)
+}
+
+func (v *emailProviderView) Object() interface{} {
+	return v.raw
+}
+
+func (r *Renderer) EmailProviderShow(email *management.Email) {
+	r.Heading("email provider")
+	r.Result(makeEmailProviderView(email))
+}
+
+func (r *Renderer) EmailProviderCreate(email *management.Email) {
+	r.Heading("email provider created")
+	r.Result(makeEmailProviderView(email))
+}
+
+func (r *Renderer) EmailProviderUpdate(email *management.Email) {
+	r.Heading("email provider updated")
+	r.Result(makeEmailProviderView(email))
+}
+
+func makeEmailProviderView(email *management.Email) *emailProviderView {
+	return &emailProviderView{
+		Provider:    emailProviderFor(email.GetName()),
+		Enabled:     boolean(email.GetEnabled()),
+		DefaultFrom: email.GetDefaultFromAddress(),
+		Settings:    emailProviderSettings(email),
+		raw:         email,
+	}
+}
+
+// emailProviderSettings lists the credentials that aren't secret, since the
+// management API never returns the secret ones.
+func emailProviderSettings(email *management.Email) [][]string {
+	c := email.Credentials
+	if c == nil {
+		return nil
+	}
+
+	switch email.GetName() {
+	case "smtp":
+		return [][]string{
+			{"SMTP HOST", c.GetSMTPHost()},
+			{"SMTP PORT", strconv.Itoa(c.GetSMTPPort())},
+			{"SMTP USER", c.GetSMTPUser()},
+		}
+	case "ses":
+		return [][]string{
+			{"ACCESS KEY ID", c.GetAccessKeyID()},
+			{"REGION", c.GetRegion()},
+		}
+	case "mailgun":
+		return [][]string{
+			{"DOMAIN", c.GetDomain()},
+			{"REGION", c.GetRegion()},
+		}
+	case "sparkpost":
+		return [][]string{
+			{"REGION", c.GetRegion()},
+		}
+	default:
+		return nil
+	}
+}
+
+func emailProviderFor(v string) string {
+	switch v {
+	case "smtp":
+		return "SMTP"
+	case "sendgrid":
+		return "SendGrid"
+	case "ses":
+		return "Amazon SES"
+	case "mailgun":
+		return "Mailgun"
+	case "sparkpost":
+		return "SparkPost"
+	case "mandrill":
+		return "Mandrill"
+	default:
+		return v
+	}
+}
@@ -1,7 +1,11 @@
 package display
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
 	"github.com/auth0/go-auth0/management"
 )
 
@@ -82,3 +86,119 @@ func makeLogStreamView(logs *management.LogStream) *logStreamView {
 		raw:    logs,
 	}
 }
+
+type logStreamStatusView struct {
+	ID        string
+	Name      string
+	Type      string
+	Status    string
+	Filters   string
+	LastError string
+	raw       interface{}
+}
+
+func (v *logStreamStatusView) AsTableHeader() []string {
+	return []string{"ID", "Name", "Type", "Status", "Filters", "Last Delivery Error"}
+}
+
+func (v *logStreamStatusView) AsTableRow() []string {
+	return []string{
+		ansi.Faint(v.ID),
+		v.Name,
+		v.Type,
+		v.Status,
+		v.Filters,
+		v.LastError,
+	}
+}
+
+func (v *logStreamStatusView) KeyValues() [][]string {
+	return [][]string{
+		{"ID", ansi.Faint(v.ID)},
+		{"NAME", v.Name},
+		{"TYPE", v.Type},
+		{"STATUS", v.Status},
+		{"FILTERS", v.Filters},
+		{"LAST DELIVERY ERROR", v.LastError},
+	}
+}
+
+func (v *logStreamStatusView) Object() interface{} {
+	return v.raw
+}
+
+// LogStreamStatus renders the health of the log streams along with the
+// categories of logs each of them receives, and the latest failure log
+// mentioning each of them, if any.
+func (r *Renderer) LogStreamStatus(streams []*management.LogStream, filters map[string][]auth0.LogStreamFilter, lastErrors map[string]*management.Log) {
+	resource := "log streams"
+
+	r.Heading(resource, "status")
+
+	if len(streams) == 0 {
+		r.EmptyState(resource)
+		r.Infof("use 'auth0 logs streams create' to create one")
+		return
+	}
+
+	var res []View
+	for _, ls := range streams {
+		res = append(res, makeLogStreamStatusView(ls, filters[ls.GetID()], lastErrors[ls.GetID()]))
+	}
+
+	if len(res) == 1 {
+		r.Result(res[0])
+	} else {
+		r.Results(res)
+	}
+
+	for _, ls := range streams {
+		if ls.GetStatus() == "suspended" {
+			r.Warnf("'%s' is suspended: Auth0 stopped delivering logs to it after repeated failures. "+
+				"Fix the sink, then run 'auth0 logs streams resume %s'.", ls.GetName(), ls.GetID())
+		}
+	}
+}
+
+func makeLogStreamStatusView(ls *management.LogStream, filters []auth0.LogStreamFilter, lastError *management.Log) *logStreamStatusView {
+	names := make([]string, 0, len(filters))
+	for _, f := range filters {
+		names = append(names, f.Name)
+	}
+
+	v := &logStreamStatusView{
+		ID:      ls.GetID(),
+		Name:    ls.GetName(),
+		Type:    ls.GetType(),
+		Status:  logStreamStatusColor(ls.GetStatus()),
+		Filters: strings.Join(names, ", "),
+		raw: struct {
+			LogStream *management.LogStream   `json:"log_stream"`
+			Filters   []auth0.LogStreamFilter `json:"filters"`
+			LastError *management.Log         `json:"last_delivery_error,omitempty"`
+		}{ls, filters, lastError},
+	}
+	if v.Filters == "" {
+		v.Filters = ansi.Faint("all logs")
+	}
+
+	v.LastError = ansi.Faint("none in the latest logs")
+	if lastError != nil {
+		v.LastError = fmt.Sprintf("%s %s", ansi.BrightRed(lastError.GetDescription()), ansi.Faint(timeAgo(lastError.GetDate())))
+	}
+
+	return v
+}
+
+func logStreamStatusColor(v string) string {
+	switch v {
+	case "active":
+		return ansi.Green(v)
+	case "paused":
+		return ansi.Yellow(v)
+	case "suspended":
+		return ansi.Red(v)
+	default:
+		return v
+	}
+}
@@ -114,6 +114,14 @@ func (v *logView) category() logCategory {
 }
 
 func (v *logView) typeDesc() (typ, desc string) {
+	typ, desc = v.typeName()
+	desc = fmt.Sprintf("%s %s", desc, auth0.StringValue(v.Description))
+	return typ, desc
+}
+
+// typeName returns the colored type code and the name of the type, e.g.
+// "Success Login".
+func (v *logView) typeName() (typ, name string) {
 	chunks := strings.Split(v.TypeName(), "(")
 
 	// NOTE(cyx): Some logs don't have a typ at all -- for those we'll
@@ -126,11 +134,9 @@ func (v *logView) typeDesc() (typ, desc string) {
 	typ = truncate(chunks[0], 23)
 
 	if len(chunks) == 2 {
-		desc = strings.TrimSuffix(chunks[1], ")")
+		name = strings.TrimSuffix(chunks[1], ")")
 	}
 
-	desc = fmt.Sprintf("%s %s", desc, auth0.StringValue(v.Description))
-
 	switch v.category() {
 	case logCategorySuccess:
 		typ = ansi.Green(typ)
@@ -142,7 +148,7 @@ func (v *logView) typeDesc() (typ, desc string) {
 		typ = ansi.Faint(typ)
 	}
 
-	return typ, desc
+	return typ, name
 }
 
 func (r *Renderer) LogList(logs []*management.Log, ch <-chan []*management.Log, silent bool) {
@@ -161,21 +167,34 @@ func (r *Renderer) LogList(logs []*management.Log, ch <-chan []*management.Log,
 		res = append(res, &logView{Log: l, silent: silent, raw: l})
 	}
 
-	var viewChan chan View
+	r.Stream(res, logViews(ch, silent))
+}
+
+// LogStreamEvents renders the logs delivered to a local log stream receiver
+// as they arrive.
+func (r *Renderer) LogStreamEvents(addr string, ch <-chan []*management.Log, silent bool) {
+	r.Heading("log stream events")
+	r.Infof("Listening for log stream events on http://%s, CTRL+C to stop.", addr)
+
+	r.Stream(nil, logViews(ch, silent))
+}
+
+func logViews(ch <-chan []*management.Log, silent bool) chan View {
+	if ch == nil {
+		return nil
+	}
 
-	if ch != nil {
-		viewChan = make(chan View)
+	viewChan := make(chan View)
 
-		go func() {
-			defer close(viewChan)
+	go func() {
+		defer close(viewChan)
 
-			for list := range ch {
-				for _, l := range list {
-					viewChan <- &logView{Log: l, silent: silent, raw: l}
-				}
+		for list := range ch {
+			for _, l := range list {
+				viewChan <- &logView{Log: l, silent: silent, raw: l}
 			}
-		}()
-	}
+		}
+	}()
 
-	r.Stream(res, viewChan)
+	return viewChan
 }
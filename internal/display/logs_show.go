@@ -0,0 +1,193 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+)
+
+type logEventView struct {
+	*auth0.LogEvent
+	raw interface{}
+}
+
+func (v *logEventView) AsTableHeader() []string {
+	return []string{}
+}
+
+func (v *logEventView) AsTableRow() []string {
+	return []string{}
+}
+
+func (v *logEventView) KeyValues() [][]string {
+	var kvs [][]string
+	add := func(k, v string) {
+		if v != "" {
+			kvs = append(kvs, []string{k, v})
+		}
+	}
+
+	lv := &logView{Log: v.Log}
+	typ, name := lv.typeName()
+
+	add("ID", ansi.Faint(v.GetLogID()))
+	if name != "" {
+		typ = fmt.Sprintf("%s (%s)", typ, name)
+	}
+	add("TYPE", fmt.Sprintf("%s %s", typ, ansi.Faint(v.GetType())))
+	add("DESCRIPTION", v.GetDescription())
+	add("DATE", fmt.Sprintf("%s %s", v.GetDate().Format(time.RFC3339), ansi.Faint(timeAgo(v.GetDate()))))
+
+	add("USER", withID(auth0.StringValue(v.UserName), v.GetUserID()))
+	add("CLIENT", withID(v.GetClientName(), v.GetClientID()))
+
+	conn := auth0.StringValue(v.Connection)
+	if conn == "" {
+		if conn = lv.getConnection(); conn == notApplicable {
+			conn = ""
+		}
+	}
+	if strategy := auth0.StringValue(v.Strategy); conn != "" && strategy != "" {
+		conn = fmt.Sprintf("%s %s", conn, ansi.Faint(strategy))
+	}
+	add("CONNECTION", conn)
+	add("AUDIENCE", auth0.StringValue(v.Audience))
+	add("SCOPE", auth0.StringValue(v.Scope))
+	add("HOSTNAME", auth0.StringValue(v.Hostname))
+
+	add("IP", v.GetIP())
+	add("LOCATION", logLocation(v.LocationInfo))
+	add("TIME ZONE", logString(v.LocationInfo, "time_zone"))
+	if lat, lng := v.LocationInfo["latitude"], v.LocationInfo["longitude"]; lat != nil && lng != nil {
+		add("COORDINATES", fmt.Sprintf("%v, %v", lat, lng))
+	}
+
+	browser, os := userAgentParts(auth0.StringValue(v.UserAgent))
+	add("BROWSER", browser)
+	add("OS", os)
+	if v.IsMobile != nil {
+		add("MOBILE", boolean(*v.IsMobile))
+	}
+
+	errMsg, errType := logError(v.Details)
+	if errMsg != "" {
+		errMsg = ansi.BrightRed(errMsg)
+	}
+	add("ERROR", errMsg)
+	add("ERROR TYPE", errType)
+	add("ACTION EXECUTIONS", strings.Join(logActionExecutions(v.Details), "\n"))
+
+	return kvs
+}
+
+func (v *logEventView) Object() interface{} {
+	return v.raw
+}
+
+func (r *Renderer) LogShow(l *auth0.LogEvent, around []*management.Log, window time.Duration) {
+	r.Heading("log")
+
+	if r.Format == OutputFormatJSON && around != nil {
+		r.JSONResult(struct {
+			Log    *auth0.LogEvent   `json:"log"`
+			Around []*management.Log `json:"around"`
+		}{l, around})
+		return
+	}
+
+	r.Result(&logEventView{LogEvent: l, raw: l})
+
+	if around == nil {
+		return
+	}
+
+	r.Heading("logs", ansi.Faint(fmt.Sprintf("within %s", window)))
+	if len(around) == 0 {
+		r.EmptyState("logs")
+		return
+	}
+
+	var res []View
+	for _, l := range around {
+		res = append(res, &logView{Log: l, silent: true, raw: l})
+	}
+	r.Stream(res, nil)
+}
+
+func withID(name, id string) string {
+	switch {
+	case name == "":
+		return id
+	case id == "":
+		return name
+	default:
+		return fmt.Sprintf("%s %s", name, ansi.Faint(id))
+	}
+}
+
+// logLocation returns the city and country of the IP of a log, e.g.
+// "Buenos Aires, Argentina".
+func logLocation(info map[string]interface{}) string {
+	var parts []string
+	for _, k := range []string{"city_name", "subdivision_name", "country_name"} {
+		if v := logString(info, k); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// userAgentParts splits the user agent of a log, which Auth0 records as
+// "<browser> / <os>", e.g. "Chrome 96.0.4664 / Mac OS X 10.15.7".
+func userAgentParts(ua string) (browser, os string) {
+	chunks := strings.SplitN(ua, " / ", 2)
+	if len(chunks) != 2 {
+		return ua, ""
+	}
+	return strings.TrimSpace(chunks[0]), strings.TrimSpace(chunks[1])
+}
+
+// logError returns the error message and type of a failure log, which are
+// either a string or an object in its details.
+func logError(details map[string]interface{}) (message, typ string) {
+	switch err := details["error"].(type) {
+	case string:
+		message = err
+	case map[string]interface{}:
+		message = logString(err, "message")
+		typ = logString(err, "oauthError")
+		if t := logString(err, "type"); t != "" {
+			typ = strings.TrimSpace(fmt.Sprintf("%s %s", typ, t))
+		}
+	}
+
+	if message == "" {
+		message = logString(details, "error_description")
+	}
+
+	return message, typ
+}
+
+// logActionExecutions returns the ids of the action executions a log relates
+// to, which can be looked up with the management API.
+func logActionExecutions(details map[string]interface{}) []string {
+	actions, _ := details["actions"].(map[string]interface{})
+	executions, _ := actions["executions"].([]interface{})
+
+	var ids []string
+	for _, e := range executions {
+		if id, ok := e.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func logString(m map[string]interface{}, key string) string {
+	v, _ := m[key].(string)
+	return v
+}
@@ -0,0 +1,83 @@
+package display
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogEventView(t *testing.T) {
+	var l auth0.LogEvent
+	err := json.Unmarshal([]byte(`{
+		"log_id": "90020211201",
+		"type": "f",
+		"date": "2021-12-01T12:00:00.000Z",
+		"description": "Wrong email or password.",
+		"client_id": "client-id",
+		"client_name": "My App",
+		"user_id": "auth0|123",
+		"user_name": "jane@example.com",
+		"connection": "Username-Password-Authentication",
+		"strategy": "auth0",
+		"ip": "190.2.3.4",
+		"user_agent": "Chrome 96.0.4664 / Mac OS X 10.15.7",
+		"isMobile": false,
+		"location_info": {
+			"city_name": "Buenos Aires",
+			"country_name": "Argentina",
+			"time_zone": "America/Argentina/Buenos_Aires",
+			"latitude": -34.6,
+			"longitude": -58.4
+		},
+		"details": {
+			"error": {"message": "Wrong email or password.", "oauthError": "invalid_grant"},
+			"actions": {"executions": ["exec-1", "exec-2"]}
+		}
+	}`), &l)
+	assert.NoError(t, err)
+
+	kvs := map[string]string{}
+	for _, kv := range (&logEventView{LogEvent: &l}).KeyValues() {
+		kvs[kv[0]] = kv[1]
+	}
+
+	assert.Contains(t, kvs["USER"], "jane@example.com")
+	assert.Contains(t, kvs["USER"], "auth0|123")
+	assert.Contains(t, kvs["CLIENT"], "My App")
+	assert.Contains(t, kvs["CONNECTION"], "Username-Password-Authentication")
+	assert.Equal(t, "190.2.3.4", kvs["IP"])
+	assert.Equal(t, "Buenos Aires, Argentina", kvs["LOCATION"])
+	assert.Equal(t, "America/Argentina/Buenos_Aires", kvs["TIME ZONE"])
+	assert.Equal(t, "-34.6, -58.4", kvs["COORDINATES"])
+	assert.Equal(t, "Chrome 96.0.4664", kvs["BROWSER"])
+	assert.Equal(t, "Mac OS X 10.15.7", kvs["OS"])
+	assert.Contains(t, kvs["ERROR"], "Wrong email or password.")
+	assert.Equal(t, "invalid_grant", kvs["ERROR TYPE"])
+	assert.Equal(t, "exec-1\nexec-2", kvs["ACTION EXECUTIONS"])
+	assert.NotContains(t, kvs, "AUDIENCE")
+}
+
+func TestLogError(t *testing.T) {
+	msg, typ := logError(map[string]interface{}{"error": "Invalid state"})
+	assert.Equal(t, "Invalid state", msg)
+	assert.Equal(t, "", typ)
+
+	msg, _ = logError(map[string]interface{}{"error_description": "Unauthorized"})
+	assert.Equal(t, "Unauthorized", msg)
+
+	msg, typ = logError(nil)
+	assert.Equal(t, "", msg)
+	assert.Equal(t, "", typ)
+}
+
+func TestUserAgentParts(t *testing.T) {
+	browser, os := userAgentParts("Firefox 94.0.0 / Linux 0.0.0")
+	assert.Equal(t, "Firefox 94.0.0", browser)
+	assert.Equal(t, "Linux 0.0.0", os)
+
+	browser, os = userAgentParts("curl/7.64.1")
+	assert.Equal(t, "curl/7.64.1", browser)
+	assert.Equal(t, "", os)
+}
@@ -0,0 +1,260 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+	"github.com/auth0/go-auth0/management"
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// LogStats aggregates logs over a period of time.
+type LogStats struct {
+	Since     time.Time      `json:"since"`
+	Until     time.Time      `json:"until"`
+	Total     int            `json:"total"`
+	Successes int            `json:"successes"`
+	Warnings  int            `json:"warnings"`
+	Failures  int            `json:"failures"`
+	Types     []LogStatCount `json:"types"`
+	Clients   []LogStatCount `json:"clients"`
+	Conns     []LogStatCount `json:"connections"`
+	IPs       []LogStatCount `json:"ips"`
+
+	// FailuresOverTime counts the failures in equal intervals between Since
+	// and Until.
+	FailuresOverTime []int `json:"failures_over_time"`
+}
+
+// LogStatCount is how many logs, and how many failures, share a value.
+type LogStatCount struct {
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+	Failures int    `json:"failures"`
+	label    string
+}
+
+// NewLogStats aggregates the logs dated between since and until, keeping the
+// top most frequent values of each dimension.
+func NewLogStats(logs []*management.Log, since, until time.Time, top, buckets int) *LogStats {
+	stats := &LogStats{
+		Since:            since,
+		Until:            until,
+		FailuresOverTime: make([]int, buckets),
+	}
+
+	types := map[string]*LogStatCount{}
+	clients := map[string]*LogStatCount{}
+	conns := map[string]*LogStatCount{}
+	ips := map[string]*LogStatCount{}
+
+	count := func(m map[string]*LogStatCount, name, label string, failed bool) {
+		if name == "" {
+			return
+		}
+		c, ok := m[name]
+		if !ok {
+			c = &LogStatCount{Name: name, label: label}
+			m[name] = c
+		}
+		c.Count++
+		if failed {
+			c.Failures++
+		}
+	}
+
+	width := until.Sub(since) / time.Duration(buckets)
+
+	for _, l := range logs {
+		if l.GetDate().Before(since) || l.GetDate().After(until) {
+			continue
+		}
+
+		v := &logView{Log: l}
+		category := v.category()
+		failed := category == logCategoryFailure
+
+		stats.Total++
+		switch category {
+		case logCategorySuccess:
+			stats.Successes++
+		case logCategoryWarning:
+			stats.Warnings++
+		case logCategoryFailure:
+			stats.Failures++
+			if width > 0 {
+				i := int(l.GetDate().Sub(since) / width)
+				if i >= buckets {
+					i = buckets - 1
+				}
+				stats.FailuresOverTime[i]++
+			}
+		}
+
+		typ, name := v.typeName()
+		if name != "" {
+			typ = fmt.Sprintf("%s (%s)", typ, name)
+		}
+		count(types, l.GetType(), fmt.Sprintf("%s %s", typ, ansi.Faint(l.GetType())), failed)
+
+		client := l.GetClientName()
+		if client == "" {
+			client = l.GetClientID()
+		}
+		count(clients, client, client, failed)
+
+		if conn := v.getConnection(); conn != notApplicable {
+			count(conns, conn, conn, failed)
+		}
+
+		count(ips, l.GetIP(), l.GetIP(), failed)
+	}
+
+	stats.Types = topLogStatCounts(types, top)
+	stats.Clients = topLogStatCounts(clients, top)
+	stats.Conns = topLogStatCounts(conns, top)
+	stats.IPs = topLogStatCounts(ips, top)
+
+	return stats
+}
+
+func topLogStatCounts(m map[string]*LogStatCount, top int) []LogStatCount {
+	res := make([]LogStatCount, 0, len(m))
+	for _, c := range m {
+		res = append(res, *c)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+		return res[i].Name < res[j].Name
+	})
+
+	if top > 0 && len(res) > top {
+		res = res[:top]
+	}
+	return res
+}
+
+type logStatCountView struct {
+	Name     string
+	Count    string
+	Failures string
+	header   string
+	raw      interface{}
+}
+
+func (v *logStatCountView) AsTableHeader() []string {
+	return []string{v.header, "Count", "Failures"}
+}
+
+func (v *logStatCountView) AsTableRow() []string {
+	return []string{v.Name, v.Count, v.Failures}
+}
+
+func (v *logStatCountView) Object() interface{} {
+	return v.raw
+}
+
+func (r *Renderer) LogStats(stats *LogStats) {
+	r.Heading("log stats", ansi.Faint("since "+timeAgo(stats.Since)))
+
+	if r.Format == OutputFormatJSON {
+		r.JSONResult(stats)
+		return
+	}
+
+	if stats.Total == 0 {
+		r.EmptyState("logs")
+		return
+	}
+
+	fmt.Fprintf(r.ResultWriter, "%s logs: %s successes, %s warnings, %s failures\n\n",
+		ansi.Bold(strconv.Itoa(stats.Total)),
+		ansi.Green(strconv.Itoa(stats.Successes)),
+		ansi.BrightYellow(strconv.Itoa(stats.Warnings)),
+		ansi.BrightRed(strconv.Itoa(stats.Failures)))
+
+	max := 0
+	for _, n := range stats.FailuresOverTime {
+		if n > max {
+			max = n
+		}
+	}
+	fmt.Fprintf(r.ResultWriter, "Failures  %s  %s\n",
+		ansi.BrightRed(sparkline(stats.FailuresOverTime)),
+		ansi.Faint(fmt.Sprintf("max %d per %s", max, bucketWidth(stats))))
+
+	tables := []struct {
+		header string
+		counts []LogStatCount
+	}{
+		{"Event Type", stats.Types},
+		{"Client", stats.Clients},
+		{"Connection", stats.Conns},
+		{"IP", stats.IPs},
+	}
+
+	for _, t := range tables {
+		if len(t.counts) == 0 {
+			continue
+		}
+
+		var res []View
+		for _, c := range t.counts {
+			name := c.label
+			if name == "" {
+				name = c.Name
+			}
+
+			failures := strconv.Itoa(c.Failures)
+			if c.Failures > 0 {
+				failures = ansi.BrightRed(failures)
+			}
+
+			res = append(res, &logStatCountView{
+				Name:     name,
+				Count:    strconv.Itoa(c.Count),
+				Failures: failures,
+				header:   t.header,
+				raw:      c,
+			})
+		}
+
+		fmt.Fprintln(r.ResultWriter)
+		r.Results(res)
+	}
+}
+
+// sparkline draws the values relative to the largest one.
+func sparkline(values []int) string {
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if max > 0 {
+			i = v * (len(sparkTicks) - 1) / max
+		}
+		b.WriteRune(sparkTicks[i])
+	}
+	return b.String()
+}
+
+func bucketWidth(stats *LogStats) time.Duration {
+	if len(stats.FailuresOverTime) == 0 {
+		return 0
+	}
+	return (stats.Until.Sub(stats.Since) / time.Duration(len(stats.FailuresOverTime))).Round(time.Second)
+}
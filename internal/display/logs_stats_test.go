@@ -0,0 +1,60 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/auth0/auth0-cli/internal/auth0"
+	"github.com/auth0/go-auth0/management"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogStats(t *testing.T) {
+	until := time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC)
+	since := until.Add(-4 * time.Hour)
+
+	log := func(typ, client, ip string, ago time.Duration) *management.Log {
+		return &management.Log{
+			Type:       auth0.String(typ),
+			ClientName: auth0.String(client),
+			IP:         auth0.String(ip),
+			Date:       auth0.Time(until.Add(-ago)),
+			Details: map[string]interface{}{
+				"prompts": []interface{}{map[string]interface{}{"connection": "Username-Password-Authentication"}},
+			},
+		}
+	}
+
+	logs := []*management.Log{
+		log("s", "My App", "10.0.0.1", 3*time.Hour+30*time.Minute),
+		log("fp", "My App", "10.0.0.2", 30*time.Minute),
+		log("fp", "My App", "10.0.0.2", 20*time.Minute),
+		log("fp", "Other App", "10.0.0.2", 10*time.Minute),
+		log("w", "Other App", "10.0.0.3", 2*time.Hour),
+		log("f", "My App", "10.0.0.4", 5*time.Hour), // Too old
+	}
+
+	stats := NewLogStats(logs, since, until, 2, 4)
+
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 1, stats.Successes)
+	assert.Equal(t, 1, stats.Warnings)
+	assert.Equal(t, 3, stats.Failures)
+	assert.Equal(t, []int{0, 0, 0, 3}, stats.FailuresOverTime)
+
+	assert.Len(t, stats.Types, 2)
+	assert.Equal(t, "fp", stats.Types[0].Name)
+	assert.Equal(t, 3, stats.Types[0].Failures)
+
+	assert.Equal(t, "My App", stats.Clients[0].Name)
+	assert.Equal(t, 3, stats.Clients[0].Count)
+	assert.Equal(t, 2, stats.Clients[0].Failures)
+
+	assert.Equal(t, "10.0.0.2", stats.IPs[0].Name)
+	assert.Equal(t, 5, stats.Conns[0].Count)
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█▁", sparkline([]int{0, 4, 8, 0}))
+	assert.Equal(t, "▁▁", sparkline([]int{0, 0}))
+}
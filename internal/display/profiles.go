@@ -0,0 +1,64 @@
+package display
+
+import (
+	"github.com/auth0/auth0-cli/internal/ansi"
+)
+
+// Profile is a named set of defaults of the CLI.
+type Profile struct {
+	Name     string `json:"name"`
+	Tenant   string `json:"tenant,omitempty"`
+	AppID    string `json:"app_id,omitempty"`
+	Audience string `json:"audience,omitempty"`
+	Format   string `json:"format,omitempty"`
+	NoInput  bool   `json:"no_input"`
+	Active   bool   `json:"active"`
+}
+
+type profileView struct {
+	Profile
+}
+
+func (v *profileView) AsTableHeader() []string {
+	return []string{"", "Name", "Tenant", "App", "Audience", "Format", "No Input"}
+}
+
+func (v *profileView) AsTableRow() []string {
+	active := ""
+	if v.Active {
+		active = ansi.Green("→")
+	}
+
+	return []string{
+		active,
+		v.Name,
+		v.Tenant,
+		ansi.Faint(v.AppID),
+		v.Audience,
+		v.Format,
+		boolean(v.NoInput),
+	}
+}
+
+func (v *profileView) Object() interface{} {
+	return v.Profile
+}
+
+func (r *Renderer) ProfileList(profiles []Profile) {
+	resource := "profiles"
+
+	r.Heading(resource)
+
+	if len(profiles) == 0 {
+		r.EmptyState(resource)
+		r.Infof("Use 'auth0 profiles create' to add one")
+		return
+	}
+
+	var res []View
+	for _, p := range profiles {
+		res = append(res, &profileView{p})
+	}
+
+	r.Results(res)
+}
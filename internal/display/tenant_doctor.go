@@ -0,0 +1,90 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/auth0/auth0-cli/internal/ansi"
+)
+
+const (
+	DoctorPriorityHigh   = "high"
+	DoctorPriorityMedium = "medium"
+	DoctorPriorityLow    = "low"
+)
+
+var doctorPriorities = map[string]int{
+	DoctorPriorityHigh:   0,
+	DoctorPriorityMedium: 1,
+	DoctorPriorityLow:    2,
+}
+
+// DoctorCheck is the outcome of one of the checks of the tenant doctor.
+type DoctorCheck struct {
+	Name     string          `json:"name"`
+	Error    string          `json:"error,omitempty"`
+	Findings []DoctorFinding `json:"findings"`
+}
+
+// DoctorFinding is a misconfiguration found by a check, with a link to where
+// it can be fixed.
+type DoctorFinding struct {
+	Priority string `json:"priority"`
+	Check    string `json:"check"`
+	Problem  string `json:"problem"`
+	URL      string `json:"url,omitempty"`
+}
+
+func (r *Renderer) TenantDoctor(checks []DoctorCheck) {
+	r.Heading("tenant doctor")
+
+	if r.Format == OutputFormatJSON {
+		r.JSONResult(checks)
+		return
+	}
+
+	var findings []DoctorFinding
+	for _, c := range checks {
+		findings = append(findings, c.Findings...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return doctorPriorities[findings[i].Priority] < doctorPriorities[findings[j].Priority]
+	})
+
+	for _, f := range findings {
+		fmt.Fprintf(r.ResultWriter, "%s %s %s\n", ansi.BrightRed("✗"), doctorPriority(f.Priority), f.Problem)
+		if f.URL != "" {
+			fmt.Fprintf(r.ResultWriter, "           %s\n", ansi.Faint(f.URL))
+		}
+	}
+
+	if len(findings) > 0 {
+		fmt.Fprintln(r.ResultWriter)
+	}
+
+	for _, c := range checks {
+		switch {
+		case c.Error != "":
+			fmt.Fprintf(r.ResultWriter, "%s %s %s\n", ansi.Yellow("?"), c.Name, ansi.Faint("could not run: "+c.Error))
+		case len(c.Findings) == 0:
+			fmt.Fprintf(r.ResultWriter, "%s %s\n", ansi.Green("✓"), c.Name)
+		default:
+			fmt.Fprintf(r.ResultWriter, "%s %s %s\n", ansi.BrightRed("✗"), c.Name, ansi.Faint(fmt.Sprintf("%d found", len(c.Findings))))
+		}
+	}
+}
+
+func doctorPriority(priority string) string {
+	label := fmt.Sprintf("%-8s", strings.ToUpper(priority))
+
+	switch priority {
+	case DoctorPriorityHigh:
+		return ansi.BrightRed(label)
+	case DoctorPriorityMedium:
+		return ansi.BrightYellow(label)
+	default:
+		return ansi.Faint(label)
+	}
+}
@@ -1,5 +1,15 @@
 package display
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-auth0/management"
+)
+
 type tenantView struct {
 	Name string
 	raw  interface{}
@@ -30,3 +40,78 @@ func (r *Renderer) TenantList(data []string) {
 
 	r.Results(results)
 }
+
+type tenantSettingsView struct {
+	*management.Tenant
+}
+
+func (v *tenantSettingsView) AsTableHeader() []string {
+	return []string{}
+}
+
+func (v *tenantSettingsView) AsTableRow() []string {
+	return []string{}
+}
+
+func (v *tenantSettingsView) KeyValues() [][]string {
+	return [][]string{
+		{"FRIENDLY NAME", v.GetFriendlyName()},
+		{"PICTURE URL", v.GetPictureURL()},
+		{"SUPPORT EMAIL", v.GetSupportEmail()},
+		{"SUPPORT URL", v.GetSupportURL()},
+		{"SESSION LIFETIME", tenantLifetime(v.SessionLifetime)},
+		{"IDLE SESSION LIFETIME", tenantLifetime(v.IdleSessionLifetime)},
+		{"DEFAULT AUDIENCE", v.GetDefaultAudience()},
+		{"DEFAULT DIRECTORY", v.GetDefaultDirectory()},
+		{"DEFAULT REDIRECTION URI", v.GetDefaultRedirectionURI()},
+		{"ENABLED LOCALES", strings.Join(interfaceSliceToString(v.EnabledLocales), ", ")},
+		{"ALLOWED LOGOUT URLS", strings.Join(interfaceSliceToString(v.AllowedLogoutURLs), "\n")},
+		{"SANDBOX VERSION", v.GetSandboxVersion()},
+		{"FLAGS", tenantFlags(v.Flags)},
+	}
+}
+
+func (v *tenantSettingsView) Object() interface{} {
+	return v.Tenant
+}
+
+func (r *Renderer) TenantSettingsShow(t *management.Tenant) {
+	r.Heading("tenant settings")
+	r.Result(&tenantSettingsView{t})
+}
+
+func (r *Renderer) TenantSettingsUpdate(t *management.Tenant) {
+	r.Heading("tenant settings updated")
+	r.Result(&tenantSettingsView{t})
+}
+
+// tenantLifetime shows a session lifetime, which the API sets in hours.
+func tenantLifetime(hours *float64) string {
+	if hours == nil {
+		return ""
+	}
+	return (time.Duration(*hours * float64(time.Hour))).String()
+}
+
+// tenantFlags lists the flags of the tenant, one per line, sorted by name.
+func tenantFlags(flags *management.TenantFlags) string {
+	if flags == nil {
+		return ""
+	}
+
+	var values map[string]bool
+	b, _ := json.Marshal(flags)
+	_ = json.Unmarshal(b, &values)
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("%s %s", boolean(values[name]), name))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,283 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrNoFixture is returned when replaying a request which wasn't recorded.
+var ErrNoFixture = errors.New("no fixture recorded")
+
+var fixtureNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fixture is a request and the response it got, stored in a file of its own.
+// Credentials are redacted, so that fixtures can be committed.
+type fixture struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		fixtureBody
+	} `json:"request"`
+
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header,omitempty"`
+		fixtureBody
+	} `json:"response"`
+}
+
+type fixtureBody struct {
+	Body string `json:"body,omitempty"`
+	// Encoding is base64 for bodies which aren't text, e.g. archives.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+func newFixtureBody(b []byte) fixtureBody {
+	if utf8.Valid(b) {
+		return fixtureBody{Body: string(b)}
+	}
+	return fixtureBody{Body: base64.StdEncoding.EncodeToString(b), Encoding: "base64"}
+}
+
+func (b fixtureBody) bytes() ([]byte, error) {
+	if b.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(b.Body)
+	}
+	return []byte(b.Body), nil
+}
+
+// Record records the requests sent through it, and their responses, to
+// fixtures in a directory, which Replay can then respond with.
+type Record struct {
+	Base http.RoundTripper
+
+	// Dir receives a file per request, numbered after the ones it already
+	// holds.
+	Dir string
+
+	mu   sync.Mutex
+	next int
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Record) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	var f fixture
+	f.Request.Method = req.Method
+	f.Request.URL = redactURL(req.URL)
+	f.Request.Header = redactHeaders(req.Header)
+	f.Request.fixtureBody = newFixtureBody(redactBody(reqBody, req.Header.Get("Content-Type")))
+	f.Response.Status = res.StatusCode
+	f.Response.Header = redactHeaders(res.Header)
+	f.Response.fixtureBody = newFixtureBody(redactBody(resBody, res.Header.Get("Content-Type")))
+
+	if err := t.write(f); err != nil {
+		return nil, fmt.Errorf("unable to record %s %s: %w", req.Method, req.URL.Path, err)
+	}
+
+	return res, nil
+}
+
+func (t *Record) write(f fixture) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.next == 0 {
+		if err := os.MkdirAll(t.Dir, 0700); err != nil {
+			return err
+		}
+		names, err := fixtureFiles(t.Dir)
+		if err != nil {
+			return err
+		}
+		t.next = len(names) + 1
+	}
+
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(f.Request.URL)
+	if err != nil {
+		return err
+	}
+	slug := strings.Trim(fixtureNameChars.ReplaceAllString(strings.ToLower(u.Path), "_"), "_")
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+
+	name := fmt.Sprintf("%04d-%s-%s.json", t.next, strings.ToLower(f.Request.Method), slug)
+	if err := ioutil.WriteFile(filepath.Join(t.Dir, name), append(b, '\n'), 0600); err != nil {
+		return err
+	}
+
+	t.next++
+	return nil
+}
+
+func (t *Record) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// Replay responds to requests with the fixtures recorded by Record, without
+// sending them. A request is answered by the first fixture not used yet with
+// the same method, path, query and body, whichever the host, so that a
+// sequence of identical requests gets the responses in the recorded order.
+type Replay struct {
+	mu       sync.Mutex
+	fixtures []fixture
+	used     []bool
+}
+
+// NewReplay loads the fixtures of a directory.
+func NewReplay(dir string) (*Replay, error) {
+	names, err := fixtureFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the fixtures: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no fixtures found in %s", dir)
+	}
+
+	r := &Replay{used: make([]bool, len(names))}
+	for _, name := range names {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the fixture %s: %w", name, err)
+		}
+
+		var f fixture
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", name, err)
+		}
+		r.fixtures = append(r.fixtures, f)
+	}
+
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Replay) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	path := fixturePath(req.URL)
+	body := string(redactBody(reqBody, req.Header.Get("Content-Type")))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, f := range t.fixtures {
+		if t.used[i] || f.Request.Method != req.Method {
+			continue
+		}
+
+		u, err := url.Parse(f.Request.URL)
+		if err != nil || fixturePath(u) != path {
+			continue
+		}
+
+		recorded, err := f.Request.bytes()
+		if err != nil || string(recorded) != body {
+			continue
+		}
+
+		resBody, err := f.Response.bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		t.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", f.Response.Status, http.StatusText(f.Response.Status)),
+			StatusCode:    f.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        f.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(resBody)),
+			ContentLength: int64(len(resBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s %s", ErrNoFixture, req.Method, path)
+}
+
+// fixturePath is the redacted path and query of a URL.
+func fixturePath(u *url.URL) string {
+	p := u.EscapedPath()
+	if u.RawQuery != "" {
+		p += "?" + redactValues(u.Query()).Encode()
+	}
+	return p
+}
+
+func fixtureFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func redactHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	redactedHeader := make(http.Header, len(h))
+	for name, vs := range h {
+		for _, v := range vs {
+			redactedHeader.Add(name, redactHeader(name, v))
+		}
+	}
+	return redactedHeader
+}
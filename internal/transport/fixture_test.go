@@ -0,0 +1,167 @@
+package transport
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/api/v2/clients":
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"client_id":"new","client_secret":"s3cr3t"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"client_id":"` + r.URL.Query().Get("page") + `"}]`))
+		case "/quickstart.zip":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write([]byte{0x50, 0x4b, 0xff, 0xfe})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := filepath.Join(t.TempDir(), "fixtures")
+
+	type exchange struct {
+		method, path, body string
+		status             int
+		response           string
+	}
+
+	send := func(t *testing.T, rt http.RoundTripper, host string, e exchange) {
+		t.Helper()
+
+		req, err := http.NewRequest(e.method, host+e.path, strings.NewReader(e.body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer t0k3n")
+		if e.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := (&http.Client{Transport: rt}).Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		b, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, e.status, res.StatusCode)
+		assert.Equal(t, e.response, string(b))
+	}
+
+	recorded := []exchange{
+		{http.MethodGet, "/api/v2/clients?page=0", "", http.StatusOK, `[{"client_id":"0"}]`},
+		{http.MethodGet, "/api/v2/clients?page=1", "", http.StatusOK, `[{"client_id":"1"}]`},
+		{http.MethodPost, "/api/v2/clients", `{"name":"app"}`, http.StatusCreated, `{"client_id":"new","client_secret":"s3cr3t"}`},
+		{http.MethodGet, "/quickstart.zip", "", http.StatusOK, "PK\xff\xfe"},
+		{http.MethodGet, "/missing", "", http.StatusNotFound, ""},
+	}
+
+	t.Run("records the requests", func(t *testing.T) {
+		rec := &Record{Dir: dir}
+		for _, e := range recorded {
+			send(t, rec, srv.URL, e)
+		}
+
+		names, err := fixtureFiles(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"0001-get-api_v2_clients.json",
+			"0002-get-api_v2_clients.json",
+			"0003-post-api_v2_clients.json",
+			"0004-get-quickstart_zip.json",
+			"0005-get-missing.json",
+		}, names)
+
+		for _, name := range names {
+			b, err := ioutil.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			assert.NotContains(t, string(b), "t0k3n")
+			assert.NotContains(t, string(b), "s3cr3t")
+		}
+	})
+
+	t.Run("numbers the fixtures after the recorded ones", func(t *testing.T) {
+		more := filepath.Join(t.TempDir(), "fixtures")
+		for i := 0; i < 2; i++ {
+			send(t, &Record{Dir: more}, srv.URL, recorded[0])
+		}
+
+		names, err := fixtureFiles(more)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0001-get-api_v2_clients.json", "0002-get-api_v2_clients.json"}, names)
+	})
+
+	t.Run("replays the responses without sending the requests", func(t *testing.T) {
+		replay, err := NewReplay(dir)
+		require.NoError(t, err)
+
+		before := calls
+		for _, e := range []exchange{
+			// Out of order, and to another host.
+			recorded[1],
+			recorded[3],
+			recorded[0],
+			recorded[4],
+		} {
+			send(t, replay, "https://travel0.auth0.com", e)
+		}
+
+		// Redacted, as recorded.
+		send(t, replay, srv.URL, exchange{http.MethodPost, "/api/v2/clients", `{"name":"app"}`, http.StatusCreated, `{"client_id":"new","client_secret":"[REDACTED]"}`})
+		assert.Equal(t, before, calls)
+	})
+
+	t.Run("fails when no fixture matches", func(t *testing.T) {
+		replay, err := NewReplay(dir)
+		require.NoError(t, err)
+
+		get := func(path string) error {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+			require.NoError(t, err)
+			_, err = replay.RoundTrip(req)
+			return err
+		}
+
+		assert.NoError(t, get("/api/v2/clients?page=0"))
+		assert.True(t, errors.Is(get("/api/v2/clients?page=0"), ErrNoFixture), "each fixture responds once")
+		assert.EqualError(t, get("/api/v2/clients?page=2"), "no fixture recorded for GET /api/v2/clients?page=2")
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/api/v2/clients", strings.NewReader(`{"name":"other"}`))
+		require.NoError(t, err)
+		_, err = replay.RoundTrip(req)
+		assert.True(t, errors.Is(err, ErrNoFixture), "the body differs")
+	})
+
+	t.Run("doesn't retry missing fixtures", func(t *testing.T) {
+		replay, err := NewReplay(dir)
+		require.NoError(t, err)
+
+		tr, delays := newTestTransport(time.Now())
+		tr.Base = replay
+
+		_, err = (&http.Client{Transport: tr}).Get(srv.URL + "/api/v2/roles")
+		assert.True(t, errors.Is(err, ErrNoFixture))
+		assert.Empty(t, *delays)
+	})
+
+	t.Run("fails without fixtures", func(t *testing.T) {
+		_, err := NewReplay(t.TempDir())
+		assert.Error(t, err)
+	})
+}
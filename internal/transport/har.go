@@ -0,0 +1,198 @@
+package transport
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HAR collects requests and responses into an HTTP Archive, the format browsers
+// export their network activity to. See http://www.softwareishard.com/blog/har-12-spec/.
+type HAR struct {
+	// Creator and Version identify the program which sent the requests.
+	Creator string
+	Version string
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []harNameVal `json:"cookies"`
+	Headers     []harNameVal `json:"headers"`
+	QueryString []harNameVal `json:"queryString"`
+	PostData    *harPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int          `json:"status"`
+	StatusText  string       `json:"statusText"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []harNameVal `json:"cookies"`
+	Headers     []harNameVal `json:"headers"`
+	Content     harContent   `json:"content"`
+	RedirectURL string       `json:"redirectURL"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+	// Error is why no response was received, in which case the status is 0.
+	Error string `json:"_error,omitempty"`
+}
+
+type harNameVal struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Len returns the number of requests collected.
+func (h *HAR) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.entries)
+}
+
+// WriteFile writes the archive to a file.
+func (h *HAR) WriteFile(path string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var l harLog
+	l.Log.Version = "1.2"
+	l.Log.Creator = harCreator{Name: h.Creator, Version: h.Version}
+	l.Log.Entries = h.entries
+	if l.Log.Entries == nil {
+		l.Log.Entries = []harEntry{}
+	}
+
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// The archive can reveal a lot about the tenant, even redacted.
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+func (h *HAR) add(req *http.Request, reqBody []byte, res *http.Response, resBody []byte, start time.Time, elapsed time.Duration, err error) {
+	ms := float64(elapsed) / float64(time.Millisecond)
+
+	if res == nil {
+		res = &http.Response{Proto: req.Proto, Header: http.Header{}}
+	}
+
+	e := harEntry{
+		StartedDateTime: start,
+		Time:            ms,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         redactURL(req.URL),
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameVal{},
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameVal{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Status:      res.StatusCode,
+			StatusText:  http.StatusText(res.StatusCode),
+			HTTPVersion: res.Proto,
+			Cookies:     []harNameVal{},
+			Headers:     harHeaders(res.Header),
+			Content: harContent{
+				Size:     len(resBody),
+				MimeType: res.Header.Get("Content-Type"),
+				Text:     string(redactBody(resBody, res.Header.Get("Content-Type"))),
+			},
+			RedirectURL: res.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(resBody),
+		},
+		Timings: harTimings{Wait: ms},
+	}
+
+	if err != nil {
+		e.Response.Error = err.Error()
+	}
+
+	if req.URL.RawQuery != "" {
+		for k, vs := range redactValues(req.URL.Query()) {
+			for _, v := range vs {
+				e.Request.QueryString = append(e.Request.QueryString, harNameVal{Name: k, Value: v})
+			}
+		}
+		sort.Slice(e.Request.QueryString, func(i, j int) bool {
+			return e.Request.QueryString[i].Name < e.Request.QueryString[j].Name
+		})
+	}
+
+	if len(reqBody) > 0 {
+		e.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     string(redactBody(reqBody, req.Header.Get("Content-Type"))),
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, e)
+}
+
+func harHeaders(h http.Header) []harNameVal {
+	headers := []harNameVal{}
+	for name, vs := range h {
+		for _, v := range vs {
+			headers = append(headers, harNameVal{Name: name, Value: redactHeader(name, v)})
+		}
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+	return headers
+}
@@ -0,0 +1,260 @@
+// Package transport provides the HTTP transports the CLI sends its requests
+// through.
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 5
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxDelay   = 30 * time.Second
+)
+
+// Quota is the state of the rate limit of the Management API, as reported by
+// its X-RateLimit-* headers.
+type Quota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RateLimitError is returned when a request is still rate limited after all
+// the retries, or when the limit resets too far in the future to wait for it.
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return "rate limit exceeded"
+	}
+	return fmt.Sprintf("rate limit exceeded, the quota resets at %s", e.Reset.Local().Format(time.Kitchen))
+}
+
+// RateLimit retries the requests which are rate limited, waiting for as long as
+// the X-RateLimit-Reset or Retry-After headers ask, as well as the idempotent
+// requests which fail transiently, with a jittered exponential backoff.
+type RateLimit struct {
+	Base http.RoundTripper
+
+	// MaxRetries is the number of retries of a request, 5 by default.
+	MaxRetries int
+
+	// MaxDelay caps how long to wait for a retry, 30s by default. A rate
+	// limit which resets later than that fails right away.
+	MaxDelay time.Duration
+
+	// OnQuota is called with the quota reported by each response.
+	OnQuota func(Quota)
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(req *http.Request, d time.Duration) error
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimit) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		// RoundTrippers mustn't modify the request, so retries are sent
+		// as copies with a fresh body.
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.Body != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		res, err := t.base().RoundTrip(r)
+
+		if res != nil && t.OnQuota != nil {
+			if q, ok := quota(res.Header); ok {
+				t.OnQuota(q)
+			}
+		}
+
+		retry, delay := t.retry(req, res, err, attempt)
+		if !retry {
+			return res, err
+		}
+
+		if res != nil && res.StatusCode == http.StatusTooManyRequests {
+			if attempt >= t.maxRetries() || delay > t.maxDelay() {
+				res.Body.Close()
+				return nil, &RateLimitError{Reset: t.resetAt(res.Header)}
+			}
+		} else if attempt >= t.maxRetries() {
+			return res, err
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+
+		if err := t.wait(req, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retry decides whether the request should be retried, and after how long.
+func (t *RateLimit) retry(req *http.Request, res *http.Response, err error, attempt int) (bool, time.Duration) {
+	// A body that can't be rewound can't be sent again.
+	if req.Body != nil && req.GetBody == nil {
+		return false, 0
+	}
+
+	if err != nil {
+		// Replays are deterministic, so a missing fixture stays missing.
+		if req.Context().Err() != nil || !idempotent(req.Method) || errors.Is(err, ErrNoFixture) {
+			return false, 0
+		}
+		return true, t.backoff(attempt)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		// The request wasn't processed, so even non idempotent requests
+		// can be retried.
+		if d, ok := t.retryAfter(res.Header); ok {
+			return true, d + jitter(defaultMinBackoff)
+		}
+		return true, t.backoff(attempt)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req.Method), t.backoff(attempt)
+	default:
+		return false, 0
+	}
+}
+
+// retryAfter reads how long to wait before the rate limit resets.
+func (t *RateLimit) retryAfter(h http.Header) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if s, err := strconv.Atoi(v); err == nil {
+			return time.Duration(s) * time.Second, true
+		}
+		if d, err := http.ParseTime(v); err == nil {
+			return nonNegative(d.Sub(t.clock())), true
+		}
+	}
+
+	if reset := t.resetAt(h); !reset.IsZero() {
+		return nonNegative(reset.Sub(t.clock())), true
+	}
+
+	return 0, false
+}
+
+func (t *RateLimit) resetAt(h http.Header) time.Time {
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(reset, 0)
+}
+
+// backoff is the exponential backoff of the attempt, with full jitter.
+func (t *RateLimit) backoff(attempt int) time.Duration {
+	d := defaultMinBackoff << uint(attempt)
+	if d <= 0 || d > t.maxDelay() {
+		d = t.maxDelay()
+	}
+	return jitter(d)
+}
+
+func (t *RateLimit) wait(req *http.Request, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(req, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *RateLimit) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *RateLimit) maxRetries() int {
+	if t.MaxRetries == 0 {
+		return defaultMaxRetries
+	}
+	return t.MaxRetries
+}
+
+func (t *RateLimit) maxDelay() time.Duration {
+	if t.MaxDelay == 0 {
+		return defaultMaxDelay
+	}
+	return t.MaxDelay
+}
+
+func (t *RateLimit) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func quota(h http.Header) (Quota, bool) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return Quota{}, false
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return Quota{}, false
+	}
+
+	q := Quota{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		q.Reset = time.Unix(reset, 0)
+	}
+	return q, true
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// jitter returns a random duration in [d/2, d), so that concurrent requests
+// don't all retry at once.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,163 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer responds with the given statuses in order, and then with 200.
+func testServer(t *testing.T, headers http.Header, statuses ...int) (*httptest.Server, *[]string) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		for k, v := range headers {
+			w.Header()[k] = v
+		}
+		if len(bodies) <= len(statuses) {
+			w.WriteHeader(statuses[len(bodies)-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func newTestTransport(now time.Time) (*RateLimit, *[]time.Duration) {
+	var delays []time.Duration
+	return &RateLimit{
+		now: func() time.Time { return now },
+		sleep: func(req *http.Request, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		},
+	}, &delays
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("waits for the rate limit to reset", func(t *testing.T) {
+		reset := now.Add(3 * time.Second)
+		srv, bodies := testServer(t, http.Header{
+			"X-Ratelimit-Limit":     {"10"},
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(reset.Unix(), 10)},
+		}, http.StatusTooManyRequests)
+
+		tr, delays := newTestTransport(now)
+		var quotas []Quota
+		tr.OnQuota = func(q Quota) { quotas = append(quotas, q) }
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewBufferString(`{"name": "admin"}`))
+		require.NoError(t, err)
+
+		res, err := (&http.Client{Transport: tr}).Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		assert.Equal(t, []string{`{"name": "admin"}`, `{"name": "admin"}`}, *bodies, "the body is sent again")
+		require.Len(t, *delays, 1)
+		assert.GreaterOrEqual(t, int64((*delays)[0]), int64(3*time.Second))
+		assert.Less(t, int64((*delays)[0]), int64(4*time.Second))
+		assert.Equal(t, Quota{Limit: 10, Remaining: 0, Reset: reset}, quotas[0])
+	})
+
+	t.Run("doesn't modify the request", func(t *testing.T) {
+		srv, bodies := testServer(t, nil, http.StatusTooManyRequests, http.StatusTooManyRequests)
+		tr, _ := newTestTransport(now)
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewBufferString(`{"name": "admin"}`))
+		require.NoError(t, err)
+		body := req.Body
+
+		res, err := tr.RoundTrip(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Len(t, *bodies, 3)
+		assert.True(t, req.Body == body, "the body of the request is left as is")
+	})
+
+	t.Run("honors Retry-After", func(t *testing.T) {
+		srv, _ := testServer(t, http.Header{"Retry-After": {"7"}}, http.StatusTooManyRequests)
+		tr, delays := newTestTransport(now)
+
+		res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.GreaterOrEqual(t, int64((*delays)[0]), int64(7*time.Second))
+	})
+
+	t.Run("fails when the rate limit resets too late", func(t *testing.T) {
+		reset := now.Add(time.Hour)
+		srv, bodies := testServer(t, http.Header{
+			"X-Ratelimit-Reset": {strconv.FormatInt(reset.Unix(), 10)},
+		}, http.StatusTooManyRequests)
+		tr, _ := newTestTransport(now)
+
+		_, err := (&http.Client{Transport: tr}).Get(srv.URL)
+
+		var rateLimitErr *RateLimitError
+		require.True(t, errors.As(err, &rateLimitErr))
+		assert.Equal(t, reset, rateLimitErr.Reset)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("gives up after the max retries", func(t *testing.T) {
+		srv, bodies := testServer(t, nil, 429, 429, 429, 429)
+		tr, _ := newTestTransport(now)
+		tr.MaxRetries = 2
+
+		_, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		assert.EqualError(t, err, "Get \""+srv.URL+"\": rate limit exceeded")
+		assert.Len(t, *bodies, 3)
+	})
+
+	t.Run("retries idempotent requests on transient errors", func(t *testing.T) {
+		srv, bodies := testServer(t, nil, http.StatusServiceUnavailable, http.StatusBadGateway)
+		tr, delays := newTestTransport(now)
+
+		res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Len(t, *bodies, 3)
+
+		// The backoff is exponential, with jitter.
+		assert.GreaterOrEqual(t, int64((*delays)[0]), int64(250*time.Millisecond))
+		assert.Less(t, int64((*delays)[0]), int64(500*time.Millisecond))
+		assert.GreaterOrEqual(t, int64((*delays)[1]), int64(500*time.Millisecond))
+		assert.Less(t, int64((*delays)[1]), int64(time.Second))
+	})
+
+	t.Run("doesn't retry non idempotent requests on transient errors", func(t *testing.T) {
+		srv, bodies := testServer(t, nil, http.StatusServiceUnavailable)
+		tr, _ := newTestTransport(now)
+
+		res, err := (&http.Client{Transport: tr}).Post(srv.URL, "application/json", bytes.NewBufferString("{}"))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		srv, bodies := testServer(t, nil, http.StatusInternalServerError)
+		tr, _ := newTestTransport(now)
+
+		res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		assert.Len(t, *bodies, 1)
+	})
+}
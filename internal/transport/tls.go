@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Options configures how the CLI connects to servers, for networks which
+// inspect TLS traffic or require client certificates.
+type Options struct {
+	// CAFile is a PEM bundle of certificate authorities trusted on top of
+	// the system ones.
+	CAFile string
+
+	// CertFile and KeyFile are the PEM client certificate and key presented
+	// to servers asking for one (mTLS).
+	CertFile string
+	KeyFile  string
+}
+
+// New returns a transport configured with the options. Like
+// http.DefaultTransport, it goes through the proxy set by the HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY env vars.
+func New(opts Options) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if opts == (Options{}) {
+		return t, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if opts.CAFile != "" {
+		pool, err := certPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		if opts.CertFile == "" || opts.KeyFile == "" {
+			return nil, errors.New("both a client certificate and its key are required")
+		}
+
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	t.TLSClientConfig = config
+	return t, nil
+}
+
+// certPool returns the system certificate authorities along with the ones of
+// the bundle.
+func certPool(caFile string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		// The system pool isn't available on every platform.
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificate found in the CA file %s", caFile)
+	}
+	return pool, nil
+}
@@ -0,0 +1,127 @@
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePEM(t *testing.T, name, blockType string, b []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b}), 0600))
+	return path
+}
+
+// clientCertificate writes a self-signed client certificate and its key.
+func clientCertificate(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "auth0-cli"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return writePEM(t, "cert.pem", "CERTIFICATE", cert), writePEM(t, "key.pem", "EC PRIVATE KEY", der)
+}
+
+func TestNew(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("uses the proxy of the env vars", func(t *testing.T) {
+		tr, err := New(Options{})
+		require.NoError(t, err)
+		assert.NotNil(t, tr.Proxy)
+	})
+
+	t.Run("trusts the CA file", func(t *testing.T) {
+		srv := httptest.NewTLSServer(ok)
+		t.Cleanup(srv.Close)
+
+		_, err := (&http.Client{Transport: http.DefaultTransport}).Get(srv.URL)
+		require.Error(t, err)
+
+		tr, err := New(Options{CAFile: writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)})
+		require.NoError(t, err)
+
+		res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("presents the client certificate", func(t *testing.T) {
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "auth0-cli", r.TLS.PeerCertificates[0].Subject.CommonName)
+		}))
+		srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		srv.StartTLS()
+		t.Cleanup(srv.Close)
+
+		caFile := writePEM(t, "ca.pem", "CERTIFICATE", srv.Certificate().Raw)
+
+		tr, err := New(Options{CAFile: caFile})
+		require.NoError(t, err)
+		_, err = (&http.Client{Transport: tr}).Get(srv.URL)
+		require.Error(t, err)
+
+		certFile, keyFile := clientCertificate(t)
+		tr, err = New(Options{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+		require.NoError(t, err)
+
+		res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("fails with invalid options", func(t *testing.T) {
+		certFile, keyFile := clientCertificate(t)
+		notPEM := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, ioutil.WriteFile(notPEM, []byte("not a certificate"), 0600))
+
+		tests := []struct {
+			name     string
+			opts     Options
+			expected string
+		}{
+			{"missing CA file", Options{CAFile: filepath.Join(t.TempDir(), "missing.pem")}, "unable to read the CA file"},
+			{"CA file without certificates", Options{CAFile: notPEM}, "no PEM certificate found"},
+			{"certificate without a key", Options{CertFile: certFile}, "both a client certificate and its key are required"},
+			{"key without a certificate", Options{KeyFile: keyFile}, "both a client certificate and its key are required"},
+			{"mismatched certificate", Options{CertFile: keyFile, KeyFile: keyFile}, "unable to load the client certificate"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				_, err := New(test.opts)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	redacted = "[REDACTED]"
+
+	// maxLoggedBody caps how much of a body is logged, which doesn't apply to
+	// the HAR archive.
+	maxLoggedBody = 4096
+)
+
+// sensitiveHeaders are redacted from the traces.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveFields are redacted from the JSON and form encoded bodies, and from
+// the query strings, as well as any field whose name contains "secret" or
+// "password". Names are compared in lower case.
+var sensitiveFields = map[string]bool{
+	"access_token":      true,
+	"api_key":           true,
+	"apikey":            true,
+	"authorization":     true,
+	"client_assertion":  true,
+	"code_verifier":     true,
+	"datadogapikey":     true,
+	"device_code":       true,
+	"httpauthorization": true,
+	"id_token":          true,
+	"refresh_token":     true,
+	"smtp_pass":         true,
+	"splunktoken":       true,
+}
+
+func sensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	return sensitiveFields[name] || strings.Contains(name, "secret") || strings.Contains(name, "password")
+}
+
+// Trace records the requests and responses sent through it, with their
+// credentials redacted.
+type Trace struct {
+	Base http.RoundTripper
+
+	// Log receives a summary of each request and response, if set.
+	Log io.Writer
+
+	// HAR collects the requests and responses, if set.
+	HAR *HAR
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Trace) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	res, err := t.base().RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.log("→ %s %s\n%s%s← %s (%s)\n\n",
+			req.Method, redactURL(req.URL), formatHeaders(req.Header), formatBody(reqBody, req.Header),
+			err, elapsed.Round(time.Millisecond))
+
+		if t.HAR != nil {
+			t.HAR.add(req, reqBody, nil, nil, start, elapsed, err)
+		}
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	t.log("→ %s %s\n%s%s← %s (%s)\n%s%s\n",
+		req.Method, redactURL(req.URL), formatHeaders(req.Header), formatBody(reqBody, req.Header),
+		res.Status, elapsed.Round(time.Millisecond), formatHeaders(res.Header), formatBody(resBody, res.Header))
+
+	if t.HAR != nil {
+		t.HAR.add(req, reqBody, res, resBody, start, elapsed, nil)
+	}
+
+	return res, nil
+}
+
+func (t *Trace) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *Trace) log(format string, a ...interface{}) {
+	if t.Log != nil {
+		fmt.Fprintf(t.Log, format, a...)
+	}
+}
+
+// readRequestBody reads the body of the request, leaving it ready to be sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+func formatHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		for _, v := range h[name] {
+			fmt.Fprintf(&b, "  %s: %s\n", name, redactHeader(name, v))
+		}
+	}
+	return b.String()
+}
+
+func formatBody(body []byte, h http.Header) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	s := string(redactBody(body, h.Get("Content-Type")))
+	if len(s) > maxLoggedBody {
+		s = s[:maxLoggedBody] + fmt.Sprintf("... (%d bytes)", len(body))
+	}
+	return "  " + strings.ReplaceAll(s, "\n", "\n  ") + "\n"
+}
+
+func redactHeader(name, value string) string {
+	if !sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+		return value
+	}
+
+	// Keep the scheme, e.g. Bearer, which tells how the request was
+	// authenticated.
+	if scheme := strings.SplitN(value, " ", 2); len(scheme) == 2 && http.CanonicalHeaderKey(name) == "Authorization" {
+		return scheme[0] + " " + redacted
+	}
+	return redacted
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	if u.RawQuery != "" {
+		c.RawQuery = redactValues(u.Query()).Encode()
+	}
+	return c.String()
+}
+
+// redactBody redacts the sensitive fields of JSON and form encoded bodies.
+// Other bodies are left untouched.
+func redactBody(body []byte, contentType string) []byte {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		return []byte(redactValues(values).Encode())
+	}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return body
+	}
+
+	b, err := json.Marshal(redactJSON(v))
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, f := range v {
+			if sensitiveField(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(f)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func redactValues(values url.Values) url.Values {
+	for k := range values {
+		// The code of form encoded bodies is an authorization code, while
+		// the one of JSON bodies is the code of an Action.
+		if sensitiveField(k) || k == "code" {
+			values[k] = []string{redacted}
+		}
+	}
+	return values
+}
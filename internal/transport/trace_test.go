@@ -0,0 +1,149 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errTransport struct{ err error }
+
+func (t errTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+func TestTrace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "did=abc")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name":"app","client_secret":"s3cr3t"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Run("logs the redacted requests and responses", func(t *testing.T) {
+		var log bytes.Buffer
+		har := &HAR{Creator: "auth0-cli", Version: "1.0.0"}
+		c := &http.Client{Transport: &Trace{Log: &log, HAR: har}}
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/api/v2/clients?fields=name&api_key=k3y", strings.NewReader(`{"name":"app","password":"hunter2"}`))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer t0k3n")
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := c.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		// The response can still be read by the caller.
+		b, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "s3cr3t")
+
+		out := log.String()
+		assert.Contains(t, out, "→ POST "+srv.URL+"/api/v2/clients?api_key=%5BREDACTED%5D&fields=name")
+		assert.Contains(t, out, "Authorization: Bearer [REDACTED]")
+		assert.Contains(t, out, `"name":"app"`)
+		assert.Contains(t, out, "← 201 Created")
+		assert.Contains(t, out, "Set-Cookie: [REDACTED]")
+		for _, secret := range []string{"t0k3n", "k3y", "hunter2", "s3cr3t", "did=abc"} {
+			assert.NotContains(t, out, secret)
+		}
+
+		assert.Equal(t, 1, har.Len())
+	})
+
+	t.Run("logs the failed requests", func(t *testing.T) {
+		var log bytes.Buffer
+		har := &HAR{}
+		c := &http.Client{Transport: &Trace{Base: errTransport{errors.New("connection refused")}, Log: &log, HAR: har}}
+
+		_, err := c.Get(srv.URL)
+		assert.Error(t, err)
+		assert.Contains(t, log.String(), "connection refused")
+		assert.Equal(t, 1, har.Len())
+	})
+
+	t.Run("writes the archive", func(t *testing.T) {
+		har := &HAR{Creator: "auth0-cli", Version: "1.0.0"}
+		c := &http.Client{Transport: &Trace{HAR: har}}
+
+		res, err := c.PostForm(srv.URL+"/oauth/token", url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {"c0d3"},
+			"client_secret": {"s3cr3t"},
+		})
+		require.NoError(t, err)
+		res.Body.Close()
+
+		path := filepath.Join(t.TempDir(), "trace.har")
+		require.NoError(t, har.WriteFile(path))
+
+		b, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "c0d3")
+		assert.NotContains(t, string(b), "s3cr3t")
+
+		var archive harLog
+		require.NoError(t, json.Unmarshal(b, &archive))
+		assert.Equal(t, "1.2", archive.Log.Version)
+		assert.Equal(t, harCreator{Name: "auth0-cli", Version: "1.0.0"}, archive.Log.Creator)
+		require.Len(t, archive.Log.Entries, 1)
+
+		e := archive.Log.Entries[0]
+		assert.Equal(t, http.MethodPost, e.Request.Method)
+		assert.Equal(t, srv.URL+"/oauth/token", e.Request.URL)
+		assert.Equal(t, "client_secret=%5BREDACTED%5D&code=%5BREDACTED%5D&grant_type=authorization_code", e.Request.PostData.Text)
+		assert.Equal(t, http.StatusCreated, e.Response.Status)
+		assert.Equal(t, "application/json", e.Response.Content.MimeType)
+	})
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		expected    string
+	}{
+		{
+			name:        "nested JSON fields",
+			body:        `{"options":{"client_secret":"x","name":"y"},"secrets":[{"value":"z"}],"n":1}`,
+			contentType: "application/json",
+			expected:    `{"n":1,"options":{"client_secret":"[REDACTED]","name":"y"},"secrets":"[REDACTED]"}`,
+		},
+		{
+			name:     "JSON without a content type",
+			body:     `{"refresh_token":"x"}`,
+			expected: `{"refresh_token":"[REDACTED]"}`,
+		},
+		{
+			name:        "form fields",
+			body:        "device_code=x&client_id=y",
+			contentType: "application/x-www-form-urlencoded",
+			expected:    "client_id=y&device_code=%5BREDACTED%5D",
+		},
+		{
+			name:        "other bodies",
+			body:        "password=x",
+			contentType: "text/plain",
+			expected:    "password=x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(redactBody([]byte(test.body), test.contentType)))
+		})
+	}
+}